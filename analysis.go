@@ -0,0 +1,108 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Analysis holds everything Analyze was able to extract from a binary. The
+// fields for the stages that failed are left as their zero value and the
+// errors for those stages are in Errors.
+type Analysis struct {
+	// Version is the compiler version used to compile the binary.
+	Version *GoVersion
+	// Packages are the packages classified as part of the main project.
+	Packages []*Package
+	// Vendors are the third party packages.
+	Vendors []*Package
+	// STDLib are the standard library packages.
+	STDLib []*Package
+	// Generated are the compiler generated packages.
+	Generated []*Package
+	// Unknown are the packages that could not be classified.
+	Unknown []*Package
+	// Types are the types found in the binary.
+	Types []*GoType
+	// Moduledata is the binary's moduledata.
+	Moduledata Moduledata
+	// GoRoot is the Go root path used to compile the binary.
+	GoRoot string
+	// Errors are the errors from the stages that failed. Each error is
+	// prefixed with the name of the stage.
+	Errors []error
+}
+
+// Analyze eagerly extracts the compiler version, packages, types, moduledata
+// and Go root from the binary. Unlike the getters, a failing stage doesn't
+// stop the analysis. The error for the stage is added to Analysis.Errors
+// and the remaining stages are still tried. An error is only returned if all
+// of the stages failed, in which case it wraps the errors of all the stages.
+// The getters can still be used after Analyze, and return the cached results.
+func (f *GoFile) Analyze() (*Analysis, error) {
+	a := &Analysis{}
+	stages := 0
+	addErr := func(stage string, err error) {
+		a.Errors = append(a.Errors, fmt.Errorf("%s: %w", stage, err))
+	}
+
+	stages++
+	if v, err := f.GetCompilerVersion(); err != nil {
+		addErr("compiler version", err)
+	} else {
+		a.Version = v
+	}
+
+	stages++
+	if err := f.initPackages(); err != nil {
+		addErr("packages", err)
+	} else {
+		a.Packages = f.pkgs
+		a.Vendors = f.vendors
+		a.STDLib = f.stdPkgs
+		a.Generated = f.generated
+		a.Unknown = f.unknown
+	}
+
+	stages++
+	if md, err := f.Moduledata(); err != nil {
+		addErr("moduledata", err)
+	} else {
+		a.Moduledata = md
+	}
+
+	stages++
+	if typs, err := f.GetTypes(); err != nil {
+		addErr("types", err)
+	} else {
+		a.Types = typs
+	}
+
+	stages++
+	if goroot, err := f.GetGoRoot(); err != nil {
+		addErr("goroot", err)
+	} else {
+		a.GoRoot = goroot
+	}
+
+	if len(a.Errors) == stages {
+		return nil, errors.Join(a.Errors...)
+	}
+	return a, nil
+}
@@ -0,0 +1,53 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeCollectsErrors(t *testing.T) {
+	r := require.New(t)
+	a := assert.New(t)
+
+	fi := &FileInfo{ByteOrder: binary.LittleEndian, WordSize: 8, goversion: ResolveGoVersion("go1.22.0")}
+	f := newTestGoFile(nil, fi)
+	// Fail the package and moduledata stages.
+	f.initPackagesError = ErrNoPCLNTab
+	f.initModuleDataError = ErrSectionDoesNotExist
+
+	res, err := f.Analyze()
+	r.NoError(err)
+	r.NotNil(res)
+	a.Equal("go1.22.0", res.Version.Name)
+	a.Nil(res.Packages)
+	a.Nil(res.Types)
+	a.Empty(res.GoRoot)
+	r.Len(res.Errors, 4)
+	a.ErrorIs(res.Errors[0], ErrNoPCLNTab)
+	a.ErrorContains(res.Errors[0], "packages: ")
+	a.ErrorIs(res.Errors[1], ErrSectionDoesNotExist)
+	a.ErrorContains(res.Errors[1], "moduledata: ")
+	a.ErrorContains(res.Errors[2], "types: ")
+	a.ErrorContains(res.Errors[3], "goroot: ")
+
+}
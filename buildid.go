@@ -20,7 +20,9 @@ package gore
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
@@ -51,6 +53,10 @@ func parseBuildIDFromElf(data []byte, byteOrder binary.ByteOrder) (string, error
 		return "", fmt.Errorf("build ID does not match expected value. 0x%x parsed", tag)
 	}
 
+	// The lengths are checked so a tampered note can't read out of bounds.
+	if uint64(nameLen)+uint64(idLen) > uint64(len(data)-12) {
+		return "", fmt.Errorf("note lengths %d and %d are out of bounds", nameLen, idLen)
+	}
 	noteName := data[12 : 12+int(nameLen)]
 	if !bytes.Equal(noteName, goNoteNameELF) {
 		return "", fmt.Errorf("note name not as expected")
@@ -58,6 +64,43 @@ func parseBuildIDFromElf(data []byte, byteOrder binary.ByteOrder) (string, error
 	return string(data[16 : 16+int(idLen)]), nil
 }
 
+// BuildIDRaw returns the name field and the raw bytes of the ELF note that holds
+// the Go build ID, stored in the ".note.go.buildid" section. The data is the
+// whole note, including the header and the name, so a malformed note can be
+// inspected. The zero bytes that pad the name are removed. If the name can't be
+// read, the data is returned together with the error. Only ELF files have the
+// note, for other files ErrSectionDoesNotExist is returned.
+func (f *GoFile) BuildIDRaw() (noteName string, data []byte, err error) {
+	_, data, err = f.fh.getSectionData(".note.go.buildid")
+	if err != nil {
+		return "", nil, err
+	}
+	data = bytes.Clone(data)
+	if len(data) < 12 {
+		return "", data, errors.New("build ID note header is truncated")
+	}
+	nameLen := f.FileInfo.ByteOrder.Uint32(data)
+	if uint64(nameLen) > uint64(len(data)-12) {
+		return "", data, fmt.Errorf("build ID note name length %d is out of bounds", nameLen)
+	}
+	return strings.TrimRight(string(data[12:12+nameLen]), "\x00"), data, nil
+}
+
+// BuildIDParts returns the action ID and the content ID of the Go build ID.
+// The build ID of a binary has the form
+// "actionID(binary)/actionID(main.a)/contentID(main.a)/contentID(binary)", so
+// the first element is the action ID and the last the content ID. The action
+// ID is a hash of the build inputs while the content ID is a hash of the
+// output, which makes it the part to use to correlate binaries. If the build
+// ID doesn't have at least two elements, empty strings are returned.
+func (f *GoFile) BuildIDParts() (actionID, contentID string) {
+	parts := strings.Split(f.BuildID, "/")
+	if len(parts) < 2 {
+		return "", ""
+	}
+	return parts[0], parts[len(parts)-1]
+}
+
 func parseBuildIDFromRaw(data []byte) (string, error) {
 	idx := bytes.Index(data, goNoteRawStart)
 	if idx < 0 {
@@ -19,10 +19,12 @@ package gore
 
 import (
 	"bytes"
+	"debug/elf"
 	"encoding/binary"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseBuildIDElf(t *testing.T) {
@@ -45,6 +47,86 @@ func TestParseBuildIDElf(t *testing.T) {
 	assert.Equal(expectedID, actual, "Extracted ID does not match.")
 }
 
+func TestParseBuildIDElfOutOfBounds(t *testing.T) {
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.LittleEndian, []uint32{4, 0xffff, 4})
+	buf.Write([]byte("Go\x00\x00abc"))
+
+	_, err := parseBuildIDFromElf(buf.Bytes(), binary.LittleEndian)
+	assert.Error(t, err)
+}
+
+func TestBuildIDRaw(t *testing.T) {
+	note := func(name string, nameLen uint32) []byte {
+		buf := &bytes.Buffer{}
+		binary.Write(buf, binary.LittleEndian, []uint32{nameLen, 3, 4})
+		buf.WriteString(name)
+		buf.WriteString("abc")
+		return buf.Bytes()
+	}
+	open := func(t *testing.T, data []byte) *GoFile {
+		f, err := OpenReader(bytes.NewReader(buildTestELF(elf.EM_X86_64, elf.ELFOSABI_NONE, testELFNote{".note.go.buildid", data})))
+		require.NoError(t, err)
+		return f
+	}
+
+	t.Run("go note", func(t *testing.T) {
+		r := require.New(t)
+		data := note("Go\x00\x00", 4)
+		name, raw, err := open(t, data).BuildIDRaw()
+		r.NoError(err)
+		r.Equal("Go", name)
+		r.Equal(data, raw)
+	})
+
+	t.Run("tampered name", func(t *testing.T) {
+		r := require.New(t)
+		data := note("XX\x00\x00", 4)
+		f := open(t, data)
+		r.Empty(f.BuildID)
+		name, raw, err := f.BuildIDRaw()
+		r.NoError(err)
+		r.Equal("XX", name)
+		r.Equal(data, raw)
+	})
+
+	t.Run("name out of bounds", func(t *testing.T) {
+		r := require.New(t)
+		data := note("Go\x00\x00", 0x100)
+		name, raw, err := open(t, data).BuildIDRaw()
+		r.Error(err)
+		r.Empty(name)
+		r.Equal(data, raw)
+	})
+
+	t.Run("no note", func(t *testing.T) {
+		f, err := OpenReader(bytes.NewReader(buildTestELF(elf.EM_X86_64, elf.ELFOSABI_NONE)))
+		require.NoError(t, err)
+		_, _, err = f.BuildIDRaw()
+		require.ErrorIs(t, err, ErrSectionDoesNotExist)
+	})
+}
+
+func TestBuildIDParts(t *testing.T) {
+	tests := []struct {
+		buildID   string
+		actionID  string
+		contentID string
+	}{
+		{"a1/a2/c2/c1", "a1", "c1"},
+		{"a1/c1", "a1", "c1"},
+		{"a1", "", ""},
+		{"", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.buildID, func(t *testing.T) {
+			actionID, contentID := (&GoFile{BuildID: test.buildID}).BuildIDParts()
+			assert.Equal(t, test.actionID, actionID)
+			assert.Equal(t, test.contentID, contentID)
+		})
+	}
+}
+
 func TestParseBuildIDRaw(t *testing.T) {
 	assert := assert.New(t)
 	expectedID := "DrtsigZmOidE-wfbFVNF/io-X8KB-ByimyyODdYUe/Z7tIlu8GbOwt0Jup-Hji/fofocVx5sk8UpaKMTx0a"
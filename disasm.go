@@ -0,0 +1,243 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"slices"
+
+	"golang.org/x/arch/x86/x86asm"
+)
+
+// archDisassembler implements the architecture specific parts of the heuristics
+// that recover data referenced by the code of a function.
+type archDisassembler interface {
+	// ResolveStringLoad searches the function's code for an instruction that
+	// references a string header, for example a global string variable. The
+	// instructions are checked in order and the data address and the length of
+	// the first string accepted by the disassembler's filter are returned.
+	ResolveStringLoad(fn *Function, buf []byte) (ptr, len uint64, ok bool)
+}
+
+// stringFilter is used to accept or reject a string resolved by an
+// archDisassembler. The string's data is passed to the filter.
+type stringFilter func(ptr uint64, data []byte) bool
+
+// x86StringLoad describes the x86 instruction that references the string
+// header searched for. The other architectures always compute the address
+// the same way so they don't need it.
+type x86StringLoad struct {
+	// op is "mov" if the string's data pointer is loaded from the header or
+	// "lea" if the address of the header is computed.
+	op x86asm.Op
+	// regs are the accepted destination registers. If empty, any register
+	// is accepted.
+	regs []x86asm.Reg
+}
+
+var (
+	// leaStringLoad matches a "lea" into any register.
+	leaStringLoad = x86StringLoad{op: x86asm.LEA}
+	// movRAXStringLoad matches a "mov" into RAX or EAX.
+	movRAXStringLoad = x86StringLoad{op: x86asm.MOV, regs: []x86asm.Reg{x86asm.RAX, x86asm.EAX}}
+	// movRAXOrECXStringLoad matches a "mov" into RAX or ECX.
+	movRAXOrECXStringLoad = x86StringLoad{op: x86asm.MOV, regs: []x86asm.Reg{x86asm.RAX, x86asm.ECX}}
+)
+
+// newArchDisassembler returns the disassembler for the file's architecture. The
+// load is the x86 instruction that references the string and the filter is used
+// to reject strings that are not the one searched for. If the architecture is not
+// supported, nil is returned.
+func newArchDisassembler(f *GoFile, load x86StringLoad, filter stringFilter) archDisassembler {
+	switch f.FileInfo.Arch {
+	case Arch386, ArchAMD64:
+		return &x86Disassembler{f: f, load: load, filter: filter}
+	case ArchARM:
+		return &armDisassembler{f: f, filter: filter}
+	case ArchARM64:
+		return &arm64Disassembler{f: f, filter: filter}
+	default:
+		return nil
+	}
+}
+
+// resolveString reads the string header at the address and returns the string
+// if it's accepted by the filter.
+func resolveString(f *GoFile, addr uint64, filter stringFilter) (uint64, uint64, bool) {
+	ptr, l, err := f.ReadStringHeader(addr)
+	if err != nil || ptr == 0 {
+		return 0, 0, false
+	}
+	data, err := f.Bytes(ptr, l)
+	if err != nil {
+		return 0, 0, false
+	}
+	if filter != nil && !filter(ptr, data) {
+		return 0, 0, false
+	}
+	return ptr, l, true
+}
+
+type x86Disassembler struct {
+	f      *GoFile
+	load   x86StringLoad
+	filter stringFilter
+}
+
+func (d *x86Disassembler) ResolveStringLoad(fn *Function, buf []byte) (uint64, uint64, bool) {
+	s := 0
+	mode := d.f.FileInfo.WordSize * 8
+
+	for s < len(buf) {
+		inst, err := x86asm.Decode(buf[s:], mode)
+		if err != nil {
+			// If we fail to decode the instruction, something is wrong so
+			// bailout.
+			return 0, 0, false
+		}
+
+		// Update next instruction location.
+		s = s + inst.Len
+
+		// Check if it's the instruction loading the string.
+		if inst.Op != d.load.op {
+			continue
+		}
+		if reg, _ := inst.Args[0].(x86asm.Reg); len(d.load.regs) > 0 && !slices.Contains(d.load.regs, reg) {
+			continue
+		}
+		arg, ok := inst.Args[1].(x86asm.Mem)
+		if !ok {
+			continue
+		}
+
+		// First, assume that the address is a direct addressing.
+		addr := arg.Disp
+		if arg.Base == x86asm.EIP || arg.Base == x86asm.RIP {
+			// If the addressing is based on the instruction pointer, fix the address.
+			addr = addr + int64(fn.Offset) + int64(s)
+		} else if arg.Base == 0 && arg.Disp > 0 {
+			// To support x32 direct addressing
+		} else {
+			// For example addressing based on the stack pointer.
+			continue
+		}
+
+		// Resolve the pointer to the string. If we get no data, this is not the
+		// right instruction.
+		if ptr, l, ok := resolveString(d.f, uint64(addr), d.filter); ok {
+			return ptr, l, true
+		}
+	}
+	return 0, 0, false
+}
+
+type arm64Disassembler struct {
+	f      *GoFile
+	filter stringFilter
+}
+
+// ResolveStringLoad looks for addresses computed with an "adrp" instruction followed by
+// an "add" or a "ldr" instruction with an immediate offset. This is how the compiler
+// references global variables.
+func (d *arm64Disassembler) ResolveStringLoad(fn *Function, buf []byte) (uint64, uint64, bool) {
+	order := d.f.FileInfo.ByteOrder
+	// pages holds the page addresses loaded into the registers by "adrp".
+	pages := make(map[uint32]uint64)
+
+	for s := 0; s+4 <= len(buf); s += 4 {
+		ins := order.Uint32(buf[s:])
+		pc := fn.Offset + uint64(s)
+		rd := ins & 0x1f
+		rn := (ins >> 5) & 0x1f
+
+		var addr uint64
+		switch {
+		case ins&0x9f000000 == 0x90000000:
+			// adrp xd, page
+			imm := int64((ins>>5)&0x7ffff)<<2 | int64((ins>>29)&0x3)
+			// Sign extend the 21-bit immediate.
+			imm = imm << 43 >> 43
+			pages[rd] = uint64(int64(pc&^0xfff) + imm<<12)
+			continue
+		case ins>>23 == 0x122:
+			// add xd, xn, #imm{, lsl #12}
+			page, ok := pages[rn]
+			if !ok {
+				continue
+			}
+			imm := uint64((ins >> 10) & 0xfff)
+			if ins&(1<<22) != 0 {
+				imm <<= 12
+			}
+			addr = page + imm
+		case ins&0xffc00000 == 0xf9400000:
+			// ldr xt, [xn, #imm]
+			page, ok := pages[rn]
+			if !ok {
+				continue
+			}
+			addr = page + uint64((ins>>10)&0xfff)*8
+		default:
+			continue
+		}
+		// The destination register has been overwritten.
+		delete(pages, rd)
+
+		if ptr, l, ok := resolveString(d.f, addr, d.filter); ok {
+			return ptr, l, true
+		}
+	}
+	return 0, 0, false
+}
+
+type armDisassembler struct {
+	f      *GoFile
+	filter stringFilter
+}
+
+// ResolveStringLoad looks for "ldr" instructions that load a word from the
+// literal pool relative to the program counter. This is how the compiler loads
+// the address of a global variable. The literal pool is placed after the code
+// of the function, so it's part of the buffer.
+func (d *armDisassembler) ResolveStringLoad(fn *Function, buf []byte) (uint64, uint64, bool) {
+	order := d.f.FileInfo.ByteOrder
+
+	for s := 0; s+4 <= len(buf); s += 4 {
+		ins := order.Uint32(buf[s:])
+		// ldr rt, [pc, #+/-imm]
+		if ins>>28 == 0xf || ins&0x0f7f0000 != 0x051f0000 {
+			continue
+		}
+		// The program counter is 8 bytes ahead of the executing instruction.
+		lit := fn.Offset + uint64(s) + 8
+		if ins&(1<<23) != 0 {
+			lit += uint64(ins & 0xfff)
+		} else {
+			lit -= uint64(ins & 0xfff)
+		}
+
+		addr, err := d.f.ReadPointer(lit)
+		if err != nil {
+			continue
+		}
+		if ptr, l, ok := resolveString(d.f, addr, d.filter); ok {
+			return ptr, l, true
+		}
+	}
+	return 0, 0, false
+}
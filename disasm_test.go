@@ -0,0 +1,142 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveStringLoad(t *testing.T) {
+	const (
+		base      = uint64(0x10000)
+		headerOff = 0x100
+		stringOff = 0x200
+	)
+
+	newFile := func(arch string, code []byte) (*GoFile, *Function) {
+		section := make([]byte, 0x300)
+		copy(section, code)
+		binary.LittleEndian.PutUint64(section[headerOff:], base+stringOff)
+		binary.LittleEndian.PutUint64(section[headerOff+8:], 5)
+		copy(section[stringOff:], "hello")
+
+		fh := &mockFileHandler{
+			mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+				if a >= base+uint64(len(section)) || a < base {
+					return 0, nil, errors.New("out of bound")
+				}
+				return base, section, nil
+			},
+		}
+		f := &GoFile{fh: fh, FileInfo: &FileInfo{Arch: arch, WordSize: intSize64, ByteOrder: binary.LittleEndian}}
+		return f, &Function{Offset: base, End: base + uint64(len(code))}
+	}
+
+	code := map[string][]byte{
+		// lea rax, [rip+0xf9]
+		ArchAMD64: {0x48, 0x8d, 0x05, 0xf9, 0x00, 0x00, 0x00},
+		// adrp x0, 0x10000; add x0, x0, #0x100
+		ArchARM64: binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(nil, 0x90000000), 0x91040000),
+	}
+
+	for arch, c := range code {
+		t.Run(arch, func(t *testing.T) {
+			f, fn := newFile(arch, c)
+
+			d := newArchDisassembler(f, leaStringLoad, func(_ uint64, data []byte) bool {
+				return bytes.HasPrefix(data, []byte("he"))
+			})
+			require.NotNil(t, d)
+			ptr, l, ok := d.ResolveStringLoad(fn, c)
+			require.True(t, ok)
+			assert.Equal(t, base+stringOff, ptr)
+			assert.Equal(t, uint64(5), l)
+
+			d = newArchDisassembler(f, leaStringLoad, func(_ uint64, data []byte) bool {
+				return bytes.HasPrefix(data, []byte("go1."))
+			})
+			_, _, ok = d.ResolveStringLoad(fn, c)
+			assert.False(t, ok, "the string should be rejected by the filter")
+		})
+	}
+
+	x86Tests := []struct {
+		name     string
+		code     []byte
+		load     x86StringLoad
+		expected bool
+	}{
+		// lea rax, [rip+0xf9]
+		{"lea", []byte{0x48, 0x8d, 0x05, 0xf9, 0x00, 0x00, 0x00}, leaStringLoad, true},
+		{"lea not mov", []byte{0x48, 0x8d, 0x05, 0xf9, 0x00, 0x00, 0x00}, movRAXStringLoad, false},
+		// mov rax, [rip+0xf9]
+		{"mov rax", []byte{0x48, 0x8b, 0x05, 0xf9, 0x00, 0x00, 0x00}, movRAXStringLoad, true},
+		{"mov not lea", []byte{0x48, 0x8b, 0x05, 0xf9, 0x00, 0x00, 0x00}, leaStringLoad, false},
+		// mov rcx, [rip+0xf9]
+		{"mov rcx", []byte{0x48, 0x8b, 0x0d, 0xf9, 0x00, 0x00, 0x00}, movRAXStringLoad, false},
+	}
+
+	for _, test := range x86Tests {
+		t.Run("x86 "+test.name, func(t *testing.T) {
+			f, fn := newFile(ArchAMD64, test.code)
+			d := newArchDisassembler(f, test.load, nil)
+			require.NotNil(t, d)
+			_, _, ok := d.ResolveStringLoad(fn, test.code)
+			assert.Equal(t, test.expected, ok)
+		})
+	}
+
+	t.Run(ArchARM, func(t *testing.T) {
+		section := make([]byte, 0x300)
+		// mov r1, r0; ldr r0, [pc, #0x4]; bx lr; <pad>; .word header
+		for i, ins := range []uint32{0xe1a01000, 0xe59f0004, 0xe12fff1e, 0, uint32(base + headerOff)} {
+			binary.LittleEndian.PutUint32(section[i*4:], ins)
+		}
+		binary.LittleEndian.PutUint32(section[headerOff:], uint32(base+stringOff))
+		binary.LittleEndian.PutUint32(section[headerOff+4:], 5)
+		copy(section[stringOff:], "hello")
+		fh := &mockFileHandler{
+			mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+				if a >= base+uint64(len(section)) || a < base {
+					return 0, nil, errors.New("out of bound")
+				}
+				return base, section, nil
+			},
+		}
+		f := &GoFile{fh: fh, FileInfo: &FileInfo{Arch: ArchARM, WordSize: intSize32, ByteOrder: binary.LittleEndian}}
+		fn := &Function{Offset: base, End: base + 20}
+
+		d := newArchDisassembler(f, leaStringLoad, nil)
+		require.NotNil(t, d)
+		ptr, l, ok := d.ResolveStringLoad(fn, section[:20])
+		require.True(t, ok)
+		assert.Equal(t, base+stringOff, ptr)
+		assert.Equal(t, uint64(5), l)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		f, _ := newFile(ArchMIPS, nil)
+		assert.Nil(t, newArchDisassembler(f, leaStringLoad, nil))
+	})
+}
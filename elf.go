@@ -18,8 +18,10 @@
 package gore
 
 import (
+	"bytes"
 	"debug/dwarf"
 	"debug/elf"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
@@ -33,6 +35,8 @@ func openELF(r io.ReaderAt) (*elfFile, error) {
 	}
 	ret := &elfFile{file: f, reader: r}
 	ret.getsymtab = sync.OnceValues(ret.initSymTab)
+	ret.getdwarf = sync.OnceValues(f.DWARF)
+	ret.os = ret.getOS()
 	return ret, nil
 }
 
@@ -42,6 +46,10 @@ type elfFile struct {
 	file      *elf.File
 	reader    io.ReaderAt
 	getsymtab func() (map[string]Symbol, error)
+	getdwarf  func() (*dwarf.Data, error)
+	closer    closeGuard
+	// os is the operating system detected by getOS when the file is opened.
+	os string
 }
 
 func (e *elfFile) initSymTab() (map[string]Symbol, error) {
@@ -76,6 +84,11 @@ func (e *elfFile) getSymbol(name string) (Symbol, error) {
 	return sym, nil
 }
 
+func (e *elfFile) hasSymbolTable() bool {
+	symm, err := e.getsymtab()
+	return err == nil && len(symm) > 0
+}
+
 func (e *elfFile) getParsedFile() any {
 	return e.file
 }
@@ -85,11 +98,13 @@ func (e *elfFile) getReader() io.ReaderAt {
 }
 
 func (e *elfFile) Close() error {
-	err := e.file.Close()
-	if err != nil {
-		return err
-	}
-	return tryClose(e.reader)
+	return e.closer.close(func() error {
+		err := e.file.Close()
+		if err != nil {
+			return err
+		}
+		return tryClose(e.reader)
+	})
 }
 
 func (e *elfFile) getRData() ([]byte, error) {
@@ -129,26 +144,76 @@ func (e *elfFile) getPCLNTABData() (uint64, []byte, error) {
 		return start, data, nil
 	}
 
-	// For files that have been linked with an external linker, the table is located
+	// For files that have been linked with an external linker, the table is usually located
 	// in the .data.rel.ro section. Because it's not in its own section, we will have to
-	// search for it in the section.
-	start, data, err := e.getSectionData(".data.rel.ro")
-	if err != nil {
-		return 0, nil, fmt.Errorf("failed to get section: .data.rel.ro: %w", err)
-	}
+	// search for it in the section. Some external linkers place the table in other sections,
+	// so if it's not in the .data.rel.ro section, the other sections are searched too.
+	for _, s := range pclntabCandidateSections(e.file) {
+		// The table can be the only content of the other sections, so a match at the
+		// start of those is accepted.
+		atStart := s.Name != ".data.rel.ro"
+		data, err := s.Data()
+		if err != nil {
+			continue
+		}
 
-	buf, err := searchSectionForTab(data, e.file.FileHeader.ByteOrder)
-	if err != nil {
-		return 0, nil, fmt.Errorf("error when search for pclntab: %w", err)
+		buf, err := searchSectionForTab(data, e.file.FileHeader.ByteOrder, atStart)
+		if err != nil {
+			continue
+		}
+
+		// Calculate the virtual address of the PCLNTAB. We don't know the size of table so
+		// we search from the end of section until we find the start of the table. Doing it
+		// this way, we can use the difference between the size of the segment and the size
+		// of the "tail" to get the offset where the table starts.
+		vaddr := s.Addr + uint64(len(data)) - uint64(len(buf))
+
+		// The magic and the header can match by chance in sections that holds other data
+		// so ensure the rest of the header is valid too.
+		if _, err := newPCLNTable(buf, vaddr, 0, e.file.FileHeader.ByteOrder); err != nil {
+			continue
+		}
+
+		return vaddr, buf, nil
 	}
 
-	// Calculate the virtual address of the PCLNTAB. We don't know the size of table so
-	// we search from the end of section until we find the start of the table. Doing it
-	// this way, we can use the difference between the size of the segment and the size
-	// of the "tail" to get the offset where the table starts.
-	vaddr := start + uint64(len(data)) - uint64(len(buf))
+	return 0, nil, fmt.Errorf("error when search for pclntab: %w", ErrNoPCLNTab)
+}
 
-	return vaddr, buf, err
+func (e *elfFile) getPCLNTABCandidates() []uint64 {
+	var addrs []uint64
+	for _, s := range []string{".gopclntab", ".data.rel.ro.gopclntab"} {
+		if sec := e.file.Section(s); sec != nil {
+			addrs = append(addrs, sec.Addr)
+		}
+	}
+	for _, s := range pclntabCandidateSections(e.file) {
+		data, err := s.Data()
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, pclntabCandidates(s.Addr, data, e.file.FileHeader.ByteOrder, s.Name != ".data.rel.ro")...)
+	}
+	return addrs
+}
+
+// pclntabCandidateSections returns the sections that may hold the pclntab in a
+// file that has been linked with an external linker. The .data.rel.ro section
+// is returned first, followed by the other sections with data that is loaded
+// into memory. Sections with code are excluded since the magic is most likely
+// to be matched by chance there.
+func pclntabCandidateSections(f *elf.File) []*elf.Section {
+	var sections []*elf.Section
+	if s := f.Section(".data.rel.ro"); s != nil {
+		sections = append(sections, s)
+	}
+	for _, s := range f.Sections {
+		if s.Type != elf.SHT_PROGBITS || s.Flags&elf.SHF_ALLOC == 0 || s.Flags&elf.SHF_EXECINSTR != 0 || s.Name == ".data.rel.ro" {
+			continue
+		}
+		sections = append(sections, s)
+	}
+	return sections
 }
 
 func (e *elfFile) moduledataSection() string {
@@ -170,6 +235,37 @@ func (e *elfFile) getSectionDataFromAddress(address uint64) (uint64, []byte, err
 	return 0, nil, ErrSectionDoesNotExist
 }
 
+func (e *elfFile) getSectionNameFromAddress(address uint64) (string, error) {
+	for _, section := range e.file.Sections {
+		if section.Offset == 0 {
+			// Only exist in memory
+			continue
+		}
+
+		if section.Addr <= address && address < (section.Addr+section.Size) {
+			return section.Name, nil
+		}
+	}
+	return "", ErrSectionDoesNotExist
+}
+
+func (e *elfFile) isExecutableAddress(address uint64) bool {
+	for _, section := range e.file.Sections {
+		if section.Flags&elf.SHF_EXECINSTR == 0 {
+			continue
+		}
+
+		if section.Addr <= address && address < (section.Addr+section.Size) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *elfFile) getEntryPoint() (uint64, error) {
+	return e.file.Entry, nil
+}
+
 func (e *elfFile) getSectionData(name string) (uint64, []byte, error) {
 	section := e.file.Section(name)
 	if section == nil {
@@ -199,16 +295,129 @@ func (e *elfFile) getFileInfo() *FileInfo {
 		arch = ArchAMD64
 	case elf.EM_ARM:
 		arch = ArchARM
+	case elf.EM_AARCH64:
+		arch = ArchARM64
+	default:
+		arch = e.file.Machine.String()
 	}
 
 	return &FileInfo{
 		ByteOrder: e.file.FileHeader.ByteOrder,
-		OS:        e.file.Machine.String(),
+		OS:        e.os,
 		WordSize:  wordSize,
 		Arch:      arch,
 	}
 }
 
+// getOS returns the operating system the file was built for. The OS ABI field
+// in the header is used first. The Go linker leaves it unset for some targets
+// so the OS specific note sections are checked next, followed by the GNU ABI
+// tag added by the system linker. If none identifies the OS, the file is
+// assumed to be for Linux. The sections are only read once, by openELF.
+func (e *elfFile) getOS() string {
+	switch e.file.OSABI {
+	case elf.ELFOSABI_LINUX:
+		return "linux"
+	case elf.ELFOSABI_FREEBSD:
+		return "freebsd"
+	case elf.ELFOSABI_NETBSD:
+		return "netbsd"
+	case elf.ELFOSABI_OPENBSD:
+		return "openbsd"
+	case elf.ELFOSABI_SOLARIS:
+		return "solaris"
+	}
+	if e.file.Section(".note.netbsd.ident") != nil {
+		return "netbsd"
+	}
+	if e.file.Section(".note.openbsd.ident") != nil {
+		return "openbsd"
+	}
+	if _, data, err := e.getSectionData(".note.ABI-tag"); err == nil {
+		if os := abiTagOS(data, e.file.ByteOrder); os != "" {
+			return os
+		}
+	}
+	if _, data, err := e.getSectionData(".note.tag"); err == nil {
+		if os := noteTagOS(data, e.file.ByteOrder); os != "" {
+			return os
+		}
+	}
+	// The Go linker doesn't mark Solaris and DragonFly binaries with an OS
+	// ABI or a note but they are always dynamically linked, so they can be
+	// recognized by the dynamic linker they request.
+	if _, data, err := e.getSectionData(".interp"); err == nil {
+		if os, ok := interpOSNames[string(bytes.TrimRight(data, "\x00"))]; ok {
+			return os
+		}
+	}
+	return "linux"
+}
+
+// interpOSNames maps the dynamic linkers requested by binaries to the GOOS
+// names. Only the systems that can't be detected from the ELF header or the
+// notes are included. Illumos uses the same dynamic linker as Solaris so
+// these binaries are reported as Solaris binaries by the file handler.
+var interpOSNames = map[string]string{
+	"/lib/ld.so.1":             "solaris",
+	"/lib/amd64/ld.so.1":       "solaris",
+	"/lib/64/ld.so.1":          "solaris",
+	"/usr/lib/ld.so.1":         "solaris",
+	"/usr/lib/amd64/ld.so.1":   "solaris",
+	"/usr/libexec/ld-elf.so.2": "dragonfly",
+}
+
+// noteTagOSNames maps the note names in the ".note.tag" section to the GOOS
+// names.
+var noteTagOSNames = map[string]string{
+	"DragonFly": "dragonfly",
+	"FreeBSD":   "freebsd",
+	"NetBSD":    "netbsd",
+}
+
+// noteTagOS returns the OS from the data of the ".note.tag" section, which
+// the BSD systems use to tag their binaries. An empty string is returned if
+// the note can't be parsed.
+func noteTagOS(data []byte, byteOrder binary.ByteOrder) string {
+	if len(data) < 12 {
+		return ""
+	}
+	nameLen := uint64(byteOrder.Uint32(data))
+	if nameLen == 0 || 12+nameLen > uint64(len(data)) {
+		return ""
+	}
+	name := bytes.TrimRight(data[12:12+nameLen], "\x00")
+	return noteTagOSNames[string(name)]
+}
+
+// abiTagOSNames maps the OS values in the GNU ABI tag note to the GOOS names.
+var abiTagOSNames = map[uint32]string{
+	0: "linux",
+	1: "hurd",
+	2: "solaris",
+	3: "freebsd",
+}
+
+// abiTagOS returns the OS from the data of the ".note.ABI-tag" section. The
+// note has the name "GNU" and the first word of the description is the OS.
+// An empty string is returned if the note can't be parsed.
+func abiTagOS(data []byte, byteOrder binary.ByteOrder) string {
+	const ntGNUABITag = 1
+	if len(data) < 12 {
+		return ""
+	}
+	nameLen := byteOrder.Uint32(data)
+	descLen := byteOrder.Uint32(data[4:])
+	if byteOrder.Uint32(data[8:]) != ntGNUABITag || nameLen != 4 || descLen < 4 {
+		return ""
+	}
+	// The name is padded to 4 bytes, which it already is.
+	if len(data) < 20 || !bytes.Equal(data[12:16], []byte("GNU\x00")) {
+		return ""
+	}
+	return abiTagOSNames[byteOrder.Uint32(data[16:])]
+}
+
 func (e *elfFile) getBuildID() (string, error) {
 	_, data, err := e.getSectionData(".note.go.buildid")
 	// If the note section does not exist, we just ignore the build id.
@@ -222,5 +431,5 @@ func (e *elfFile) getBuildID() (string, error) {
 }
 
 func (e *elfFile) getDwarf() (*dwarf.Data, error) {
-	return e.file.DWARF()
+	return e.getdwarf()
 }
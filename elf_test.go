@@ -0,0 +1,238 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testELFNote is a note section added to the file built by buildTestELF.
+type testELFNote struct {
+	name string
+	data []byte
+}
+
+// buildTestELF creates a minimal 64-bit executable with the file header and
+// the given note sections.
+func buildTestELF(machine elf.Machine, osabi elf.OSABI, notes ...testELFNote) []byte {
+	hdrSize := binary.Size(elf.Header64{})
+	shdrSize := binary.Size(elf.Section64{})
+
+	// The section name table follows the header, then the note data and
+	// last the section headers.
+	shstrtab := []byte{0}
+	var names []uint32
+	for _, n := range append(notes, testELFNote{name: ".shstrtab"}) {
+		names = append(names, uint32(len(shstrtab)))
+		shstrtab = append(append(shstrtab, n.name...), 0)
+	}
+	sections := []elf.Section64{{}}
+	off := hdrSize + len(shstrtab)
+	for i, n := range notes {
+		sections = append(sections, elf.Section64{
+			Name: names[i],
+			Type: uint32(elf.SHT_NOTE),
+			Off:  uint64(off),
+			Size: uint64(len(n.data)),
+		})
+		off += len(n.data)
+	}
+	sections = append(sections, elf.Section64{
+		Name: names[len(notes)],
+		Type: uint32(elf.SHT_STRTAB),
+		Off:  uint64(hdrSize),
+		Size: uint64(len(shstrtab)),
+	})
+
+	hdr := elf.Header64{
+		Type:      uint16(elf.ET_EXEC),
+		Machine:   uint16(machine),
+		Version:   uint32(elf.EV_CURRENT),
+		Shoff:     uint64(off),
+		Ehsize:    uint16(hdrSize),
+		Shentsize: uint16(shdrSize),
+		Shnum:     uint16(len(sections)),
+		Shstrndx:  uint16(len(sections) - 1),
+	}
+	copy(hdr.Ident[:], elf.ELFMAG)
+	hdr.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	hdr.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	hdr.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+	hdr.Ident[elf.EI_OSABI] = byte(osabi)
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, hdr)
+	buf.Write(shstrtab)
+	for _, n := range notes {
+		buf.Write(n.data)
+	}
+	binary.Write(&buf, binary.LittleEndian, sections)
+	return buf.Bytes()
+}
+
+// buildABITagNote creates the data of a ".note.ABI-tag" section for the OS.
+func buildABITagNote(os uint32) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, []uint32{4, 16, 1})
+	buf.WriteString("GNU\x00")
+	binary.Write(&buf, binary.LittleEndian, []uint32{os, 2, 6, 32})
+	return buf.Bytes()
+}
+
+// buildNoteTag returns a ".note.tag" note with the name, like the notes the BSD
+// systems tag their binaries with.
+func buildNoteTag(name string) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, []uint32{uint32(len(name) + 1), 4, 1})
+	buf.WriteString(name)
+	buf.Write(make([]byte, 4-len(name)%4))
+	binary.Write(&buf, binary.LittleEndian, uint32(600000))
+	return buf.Bytes()
+}
+
+func TestELFFileInfoArch(t *testing.T) {
+	tests := []struct {
+		machine  elf.Machine
+		expected string
+	}{
+		{elf.EM_X86_64, ArchAMD64},
+		{elf.EM_386, Arch386},
+		{elf.EM_ARM, ArchARM},
+		{elf.EM_AARCH64, ArchARM64},
+		{elf.EM_MIPS, ArchMIPS},
+		{elf.EM_RISCV, "EM_RISCV"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			fh, err := openELF(bytes.NewReader(buildTestELF(test.machine, elf.ELFOSABI_NONE)))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, fh.getFileInfo().Arch)
+		})
+	}
+}
+
+func TestELFFileInfoOS(t *testing.T) {
+	tests := []struct {
+		name     string
+		osabi    elf.OSABI
+		notes    []testELFNote
+		expected string
+	}{
+		{"no abi", elf.ELFOSABI_NONE, nil, "linux"},
+		{"linux abi", elf.ELFOSABI_LINUX, nil, "linux"},
+		{"freebsd abi", elf.ELFOSABI_FREEBSD, nil, "freebsd"},
+		{"netbsd abi", elf.ELFOSABI_NETBSD, nil, "netbsd"},
+		{"openbsd abi", elf.ELFOSABI_OPENBSD, nil, "openbsd"},
+		{"solaris abi", elf.ELFOSABI_SOLARIS, nil, "solaris"},
+		{"netbsd note", elf.ELFOSABI_NONE, []testELFNote{{name: ".note.netbsd.ident"}}, "netbsd"},
+		{"openbsd note", elf.ELFOSABI_NONE, []testELFNote{{name: ".note.go.buildid"}, {name: ".note.openbsd.ident"}}, "openbsd"},
+		{"other note", elf.ELFOSABI_NONE, []testELFNote{{name: ".note.go.buildid"}}, "linux"},
+		{"linux abi tag", elf.ELFOSABI_NONE, []testELFNote{{".note.ABI-tag", buildABITagNote(0)}}, "linux"},
+		{"solaris abi tag", elf.ELFOSABI_NONE, []testELFNote{{".note.ABI-tag", buildABITagNote(2)}}, "solaris"},
+		{"freebsd abi tag", elf.ELFOSABI_NONE, []testELFNote{{".note.ABI-tag", buildABITagNote(3)}}, "freebsd"},
+		{"unknown abi tag", elf.ELFOSABI_NONE, []testELFNote{{".note.ABI-tag", buildABITagNote(42)}}, "linux"},
+		{"truncated abi tag", elf.ELFOSABI_NONE, []testELFNote{{".note.ABI-tag", buildABITagNote(3)[:18]}}, "linux"},
+		{"dragonfly note tag", elf.ELFOSABI_NONE, []testELFNote{{".note.tag", buildNoteTag("DragonFly")}}, "dragonfly"},
+		{"truncated note tag", elf.ELFOSABI_NONE, []testELFNote{{".note.tag", buildNoteTag("DragonFly")[:14]}}, "linux"},
+		{"freebsd note tag", elf.ELFOSABI_NONE, []testELFNote{{".note.tag", buildNoteTag("FreeBSD")}}, "freebsd"},
+		{"netbsd note tag", elf.ELFOSABI_NONE, []testELFNote{{".note.tag", buildNoteTag("NetBSD")}}, "netbsd"},
+		{"unknown note tag", elf.ELFOSABI_NONE, []testELFNote{{".note.tag", buildNoteTag("Plan9")}}, "linux"},
+		{"solaris interp", elf.ELFOSABI_NONE, []testELFNote{{".interp", []byte("/lib/amd64/ld.so.1\x00")}}, "solaris"},
+		{"solaris 32-bit interp", elf.ELFOSABI_NONE, []testELFNote{{".interp", []byte("/lib/ld.so.1\x00")}}, "solaris"},
+		{"solaris 64 interp", elf.ELFOSABI_NONE, []testELFNote{{".interp", []byte("/lib/64/ld.so.1\x00")}}, "solaris"},
+		{"solaris usr interp", elf.ELFOSABI_NONE, []testELFNote{{".interp", []byte("/usr/lib/ld.so.1\x00")}}, "solaris"},
+		{"solaris usr amd64 interp", elf.ELFOSABI_NONE, []testELFNote{{".interp", []byte("/usr/lib/amd64/ld.so.1\x00")}}, "solaris"},
+		{"interp without terminator", elf.ELFOSABI_NONE, []testELFNote{{".interp", []byte("/lib/amd64/ld.so.1")}}, "solaris"},
+		{"note tag before interp", elf.ELFOSABI_NONE, []testELFNote{{".interp", []byte("/lib/amd64/ld.so.1\x00")}, {".note.tag", buildNoteTag("DragonFly")}}, "dragonfly"},
+		{"dragonfly interp", elf.ELFOSABI_NONE, []testELFNote{{".interp", []byte("/usr/libexec/ld-elf.so.2\x00")}}, "dragonfly"},
+		{"linux interp", elf.ELFOSABI_NONE, []testELFNote{{".interp", []byte("/lib64/ld-linux-x86-64.so.2\x00")}}, "linux"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fh, err := openELF(bytes.NewReader(buildTestELF(elf.EM_X86_64, test.osabi, test.notes...)))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, fh.getFileInfo().OS)
+		})
+	}
+}
+
+func TestELFFileInfoOSDetectedOnOpen(t *testing.T) {
+	r := require.New(t)
+	fh, err := openELF(bytes.NewReader(buildTestELF(elf.EM_X86_64, elf.ELFOSABI_NONE, testELFNote{".note.tag", buildNoteTag("DragonFly")})))
+	r.NoError(err)
+
+	// The sections are not read again for the file info.
+	fh.file.Sections = nil
+	r.Equal("dragonfly", fh.getFileInfo().OS)
+	r.Equal("dragonfly", fh.getFileInfo().OS)
+}
+
+func TestELFIllumosOS(t *testing.T) {
+	// Illumos binaries request the Solaris dynamic linker, so the target OS
+	// is taken from the build settings.
+	modinfo := func(goos string) testELFNote {
+		data := append([]byte{}, modInfoStart...)
+		data = append(data, "path\texample.com/app\nbuild\tGOOS="+goos+"\n"...)
+		return testELFNote{".rodata", append(data, modInfoEnd...)}
+	}
+	interp := testELFNote{".interp", []byte("/lib/amd64/ld.so.1\x00")}
+
+	for _, goos := range []string{"illumos", "solaris"} {
+		t.Run(goos, func(t *testing.T) {
+			r := require.New(t)
+			f, err := OpenReader(bytes.NewReader(buildTestELF(elf.EM_X86_64, elf.ELFOSABI_NONE, interp, modinfo(goos))))
+			r.NoError(err)
+			r.Equal(goos, f.FileInfo.OS)
+		})
+	}
+}
+
+func TestIsGccGo(t *testing.T) {
+	t.Run("go export data", func(t *testing.T) {
+		r := require.New(t)
+		f, err := OpenReader(bytes.NewReader(buildTestELF(elf.EM_X86_64, elf.ELFOSABI_NONE, testELFNote{name: ".go_export"})))
+		r.NoError(err)
+		r.True(f.IsGccGo())
+
+		_, err = f.PCLNTab()
+		r.ErrorIs(err, ErrUnsupportedCompiler)
+		_, err = f.Moduledata()
+		r.ErrorIs(err, ErrUnsupportedCompiler)
+		_, err = f.GetPackages()
+		r.ErrorIs(err, ErrUnsupportedCompiler)
+	})
+
+	t.Run("gc", func(t *testing.T) {
+		r := require.New(t)
+		f, err := OpenReader(bytes.NewReader(buildTestELF(elf.EM_X86_64, elf.ELFOSABI_NONE, testELFNote{name: ".note.go.buildid"})))
+		r.NoError(err)
+		r.False(f.IsGccGo())
+
+		_, err = f.PCLNTab()
+		r.Error(err)
+		r.NotErrorIs(err, ErrUnsupportedCompiler)
+	})
+}
@@ -0,0 +1,360 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+const (
+	// ntAuxv is the note type for the auxiliary vector stored in core files.
+	ntAuxv = 6
+	// atEntry is the auxiliary vector key for the program's entry point.
+	atEntry = 9
+)
+
+// OpenCore opens an ELF core dump together with the executable of the process
+// that was dumped. The executable can for example be "/proc/<pid>/exe" of a
+// running process. The data that was written to the process's memory, like the
+// moduledata, is read from the core dump while data not stored in the dump, like
+// the code, is read from the executable. All addresses are the addresses used by
+// the process at the time of the dump, so for position independent executables
+// they are relocated to where the executable was loaded.
+func OpenCore(corePath, exePath string) (*GoFile, error) {
+	exe, err := os.Open(exePath)
+	if err != nil {
+		return nil, err
+	}
+	ef, err := openELF(exe)
+	if err != nil {
+		exe.Close()
+		return nil, err
+	}
+
+	core, err := os.Open(corePath)
+	if err != nil {
+		ef.Close()
+		return nil, err
+	}
+	cf, err := openELFCore(ef, core)
+	if err != nil {
+		core.Close()
+		ef.Close()
+		return nil, err
+	}
+
+	return newGoFile(cf), nil
+}
+
+var _ fileHandler = (*elfCoreFile)(nil)
+
+// elfCoreFile is a file handler for an executable and a core dump of a process
+// running the executable. Addresses are translated to the process's address
+// space by the difference between where the executable was loaded and the
+// address it was linked to use.
+type elfCoreFile struct {
+	*elfFile
+	core       *elf.File
+	coreReader io.ReaderAt
+	// segments are the loadable segments in the core file that has data,
+	// sorted by address.
+	segments []*elf.Prog
+	// segmentData holds the data read for the segments so each segment is
+	// only read once from the core file.
+	segmentData   map[*elf.Prog][]byte
+	segmentDataMu sync.Mutex
+	// sectionCache holds the data returned for the executable's sections so
+	// sections spanning multiple segments are only assembled once.
+	sectionCache   map[*elf.Section][]byte
+	sectionCacheMu sync.Mutex
+	// bias is the difference between the address the executable was loaded
+	// at and the address it was linked to use.
+	bias   uint64
+	closer closeGuard
+}
+
+func openELFCore(exe *elfFile, r io.ReaderAt) (*elfCoreFile, error) {
+	core, err := elf.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing the core file: %w", err)
+	}
+	if core.Type != elf.ET_CORE {
+		return nil, errors.New("the file is not a core file")
+	}
+	if core.Machine != exe.file.Machine || core.Class != exe.file.Class {
+		return nil, errors.New("the core file does not match the executable's architecture")
+	}
+
+	c := &elfCoreFile{elfFile: exe, core: core, coreReader: r, segmentData: make(map[*elf.Prog][]byte), sectionCache: make(map[*elf.Section][]byte)}
+	for _, p := range core.Progs {
+		if p.Type == elf.PT_LOAD && p.Filesz > 0 {
+			c.segments = append(c.segments, p)
+		}
+	}
+	sort.Slice(c.segments, func(i, j int) bool {
+		return c.segments[i].Vaddr < c.segments[j].Vaddr
+	})
+
+	// The entry point in the auxiliary vector is where the entry point of the executable
+	// is located in the process's memory.
+	if entry, ok := c.auxvValue(atEntry); ok {
+		c.bias = entry - exe.file.Entry
+	}
+
+	return c, nil
+}
+
+// auxvValue returns the value stored for the key in the process's auxiliary vector.
+func (c *elfCoreFile) auxvValue(key uint64) (uint64, bool) {
+	order := c.core.ByteOrder
+	ws := 8
+	if c.core.Class == elf.ELFCLASS32 {
+		ws = 4
+	}
+	word := func(b []byte) uint64 {
+		if ws == 4 {
+			return uint64(order.Uint32(b))
+		}
+		return order.Uint64(b)
+	}
+
+	for _, p := range c.core.Progs {
+		if p.Type != elf.PT_NOTE {
+			continue
+		}
+		notes := make([]byte, p.Filesz)
+		if _, err := p.ReadAt(notes, 0); err != nil {
+			continue
+		}
+
+		// Each note has a header of the name size, the descriptor size and the type.
+		// The name and the descriptor are padded to 4 bytes.
+		for len(notes) >= 12 {
+			namesz := uint64(order.Uint32(notes))
+			descsz := uint64(order.Uint32(notes[4:]))
+			typ := order.Uint32(notes[8:])
+			descOff := 12 + (namesz+3)&^3
+			end := descOff + (descsz+3)&^3
+			if descOff+descsz > uint64(len(notes)) {
+				break
+			}
+			if typ == ntAuxv {
+				desc := notes[descOff : descOff+descsz]
+				for len(desc) >= 2*ws {
+					if word(desc) == key {
+						return word(desc[ws:]), true
+					}
+					desc = desc[2*ws:]
+				}
+			}
+			if end > uint64(len(notes)) {
+				break
+			}
+			notes = notes[end:]
+		}
+	}
+	return 0, false
+}
+
+// segment returns the data stored in the core file for the segment. The data
+// is cached so the segment is only read once.
+func (c *elfCoreFile) segment(p *elf.Prog) ([]byte, error) {
+	c.segmentDataMu.Lock()
+	defer c.segmentDataMu.Unlock()
+
+	if data, ok := c.segmentData[p]; ok {
+		return data, nil
+	}
+	data := make([]byte, p.Filesz)
+	if _, err := p.ReadAt(data, 0); err != nil {
+		return nil, err
+	}
+	c.segmentData[p] = data
+	return data, nil
+}
+
+// memory returns the data in the core file for the memory range. False is
+// returned if the core file does not have all the data in the range. If the
+// range is within one segment, the returned data is part of the segment's
+// cached data.
+func (c *elfCoreFile) memory(addr, size uint64) ([]byte, bool) {
+	for _, p := range c.segments {
+		if addr < p.Vaddr || addr >= p.Vaddr+p.Filesz {
+			continue
+		}
+		if addr-p.Vaddr+size > p.Filesz {
+			break
+		}
+		data, err := c.segment(p)
+		if err != nil {
+			return nil, false
+		}
+		off := addr - p.Vaddr
+		return data[off : off+size : off+size], true
+	}
+
+	buf := make([]byte, size)
+	read := uint64(0)
+	for _, p := range c.segments {
+		cur := addr + read
+		if read == size {
+			break
+		}
+		if cur < p.Vaddr || cur >= p.Vaddr+p.Filesz {
+			continue
+		}
+		data, err := c.segment(p)
+		if err != nil {
+			return nil, false
+		}
+		read += uint64(copy(buf[read:], data[cur-p.Vaddr:]))
+	}
+	return buf, read == size
+}
+
+// sectionData returns the data for the executable's section. If the core file has
+// the memory for the section, it is used. Otherwise, the data is read from the
+// executable. The data is cached so it's only read once for each section.
+func (c *elfCoreFile) sectionData(s *elf.Section) (uint64, []byte, error) {
+	addr := s.Addr + c.bias
+
+	c.sectionCacheMu.Lock()
+	defer c.sectionCacheMu.Unlock()
+
+	if data, ok := c.sectionCache[s]; ok {
+		return addr, data, nil
+	}
+	data, ok := c.memory(addr, s.Size)
+	if !ok {
+		var err error
+		data, err = s.Data()
+		if err != nil {
+			return addr, nil, err
+		}
+	}
+	c.sectionCache[s] = data
+	return addr, data, nil
+}
+
+func (c *elfCoreFile) Close() error {
+	return c.closer.close(func() error {
+		// Both files are closed even if closing one of them fails.
+		return errors.Join(c.core.Close(), tryClose(c.coreReader), c.elfFile.Close())
+	})
+}
+
+func (c *elfCoreFile) getSymbol(name string) (Symbol, error) {
+	sym, err := c.elfFile.getSymbol(name)
+	if err != nil {
+		return Symbol{}, err
+	}
+	sym.Value += c.bias
+	return sym, nil
+}
+
+func (c *elfCoreFile) getCodeSection() (uint64, []byte, error) {
+	addr, data, err := c.elfFile.getCodeSection()
+	return addr + c.bias, data, err
+}
+
+func (c *elfCoreFile) getPCLNTABData() (uint64, []byte, error) {
+	addr, data, err := c.elfFile.getPCLNTABData()
+	return addr + c.bias, data, err
+}
+
+func (c *elfCoreFile) getPCLNTABCandidates() []uint64 {
+	addrs := c.elfFile.getPCLNTABCandidates()
+	for i := range addrs {
+		addrs[i] += c.bias
+	}
+	return addrs
+}
+
+func (c *elfCoreFile) getSectionData(name string) (uint64, []byte, error) {
+	section := c.file.Section(name)
+	if section == nil {
+		return 0, nil, ErrSectionDoesNotExist
+	}
+	return c.sectionData(section)
+}
+
+func (c *elfCoreFile) getSectionDataFromAddress(address uint64) (uint64, []byte, error) {
+	for _, section := range c.file.Sections {
+		if section.Offset == 0 {
+			// Only exist in memory
+			continue
+		}
+
+		start := section.Addr + c.bias
+		if start <= address && address < (start+section.Size) {
+			return c.sectionData(section)
+		}
+	}
+
+	// The address can point to memory that is not part of the executable,
+	// for example the heap.
+	for _, p := range c.segments {
+		if p.Vaddr <= address && address < p.Vaddr+p.Filesz {
+			data, err := c.segment(p)
+			if err != nil {
+				return 0, nil, err
+			}
+			return p.Vaddr, data, nil
+		}
+	}
+	return 0, nil, ErrSectionDoesNotExist
+}
+
+func (c *elfCoreFile) getSectionNameFromAddress(address uint64) (string, error) {
+	for _, section := range c.file.Sections {
+		if section.Offset == 0 {
+			// Only exist in memory
+			continue
+		}
+
+		start := section.Addr + c.bias
+		if start <= address && address < (start+section.Size) {
+			return section.Name, nil
+		}
+	}
+	return "", ErrSectionDoesNotExist
+}
+
+func (c *elfCoreFile) getEntryPoint() (uint64, error) {
+	return c.file.Entry + c.bias, nil
+}
+
+func (c *elfCoreFile) isExecutableAddress(address uint64) bool {
+	for _, section := range c.file.Sections {
+		if section.Flags&elf.SHF_EXECINSTR == 0 {
+			continue
+		}
+
+		start := section.Addr + c.bias
+		if start <= address && address < (start+section.Size) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildTestCore creates a minimal 64-bit core file with an auxiliary vector
+// note and two adjacent loadable segments.
+func buildTestCore(entry uint64, segAddr uint64, seg1, seg2 []byte) []byte {
+	le := binary.LittleEndian
+
+	var note bytes.Buffer
+	name := []byte("CORE\x00\x00\x00\x00")
+	auxv := make([]byte, 4*8)
+	le.PutUint64(auxv[0:], 6) // AT_PAGESZ
+	le.PutUint64(auxv[8:], 0x1000)
+	le.PutUint64(auxv[16:], atEntry)
+	le.PutUint64(auxv[24:], entry)
+	binary.Write(&note, le, []uint32{5, uint32(len(auxv)), ntAuxv})
+	note.Write(name)
+	note.Write(auxv)
+
+	const nprogs = 3
+	hdrSize := uint64(binary.Size(elf.Header64{}))
+	progSize := uint64(binary.Size(elf.Prog64{}))
+	noteOff := hdrSize + nprogs*progSize
+	seg1Off := noteOff + uint64(note.Len())
+	seg2Off := seg1Off + uint64(len(seg1))
+
+	hdr := elf.Header64{
+		Type:      uint16(elf.ET_CORE),
+		Machine:   uint16(elf.EM_X86_64),
+		Version:   uint32(elf.EV_CURRENT),
+		Phoff:     hdrSize,
+		Ehsize:    uint16(hdrSize),
+		Phentsize: uint16(progSize),
+		Phnum:     nprogs,
+	}
+	copy(hdr.Ident[:], elf.ELFMAG)
+	hdr.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	hdr.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	hdr.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+
+	progs := []elf.Prog64{
+		{Type: uint32(elf.PT_NOTE), Off: noteOff, Filesz: uint64(note.Len())},
+		{Type: uint32(elf.PT_LOAD), Off: seg1Off, Vaddr: segAddr, Filesz: uint64(len(seg1)), Memsz: uint64(len(seg1))},
+		{Type: uint32(elf.PT_LOAD), Off: seg2Off, Vaddr: segAddr + uint64(len(seg1)), Filesz: uint64(len(seg2)), Memsz: uint64(len(seg2))},
+	}
+
+	var buf bytes.Buffer
+	binary.Write(&buf, le, hdr)
+	binary.Write(&buf, le, progs)
+	buf.Write(note.Bytes())
+	buf.Write(seg1)
+	buf.Write(seg2)
+	return buf.Bytes()
+}
+
+func TestELFCore(t *testing.T) {
+	r := require.New(t)
+	a := assert.New(t)
+
+	const (
+		linkedEntry = uint64(0x401000)
+		loadedEntry = uint64(0x555555401000)
+		segAddr     = uint64(0xc000000000)
+	)
+
+	exe := &elfFile{file: &elf.File{FileHeader: elf.FileHeader{
+		Class:   elf.ELFCLASS64,
+		Machine: elf.EM_X86_64,
+		Entry:   linkedEntry,
+	}}}
+
+	core := buildTestCore(loadedEntry, segAddr, []byte{1, 2, 3, 4}, []byte{5, 6, 7, 8})
+	c, err := openELFCore(exe, bytes.NewReader(core))
+	r.NoError(err)
+
+	a.Equal(loadedEntry-linkedEntry, c.bias)
+
+	data, ok := c.memory(segAddr+2, 4)
+	a.True(ok, "reading across adjacent segments should succeed")
+	a.Equal([]byte{3, 4, 5, 6}, data)
+
+	_, ok = c.memory(segAddr+6, 4)
+	a.False(ok, "reading past the end of the dumped memory should fail")
+
+	// Data within a segment is not copied.
+	seg, err := c.segment(c.segments[1])
+	r.NoError(err)
+	data, ok = c.memory(segAddr+5, 2)
+	a.True(ok)
+	a.Equal([]byte{6, 7}, data)
+	a.Same(&seg[1], &data[0])
+
+	// A section spanning both segments is only assembled once.
+	section := &elf.Section{SectionHeader: elf.SectionHeader{Addr: segAddr - c.bias + 1, Size: 6}}
+	addr, first, err := c.sectionData(section)
+	r.NoError(err)
+	a.Equal(segAddr+1, addr)
+	a.Equal([]byte{2, 3, 4, 5, 6, 7}, first)
+	_, second, err := c.sectionData(section)
+	r.NoError(err)
+	a.Same(&first[0], &second[0])
+
+	base, data, err := c.getSectionDataFromAddress(segAddr + 5)
+	r.NoError(err)
+	a.Equal(segAddr+4, base)
+	a.Equal([]byte{5, 6, 7, 8}, data)
+}
@@ -0,0 +1,199 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxEmbeddedFiles is an upper limit on the number of files in an embed.FS.
+// It is used to quickly discard data that can't be a file table.
+const maxEmbeddedFiles = 1 << 16
+
+// EmbeddedFile is a file embedded in the binary with the "go:embed" directive.
+type EmbeddedFile struct {
+	// Name is the slash separated path of the file. Directory names end with
+	// a slash.
+	Name string
+	// Data is the content of the file. It is empty for directories.
+	Data []byte
+}
+
+// EmbeddedFiles returns the files embedded in the binary via embed.FS values.
+// The embedded files were added in Go 1.16. If the binary does not use
+// embed.FS, an empty slice is returned.
+//
+// Each embed.FS value holds a pointer to a sorted table of files. The variables
+// are located by searching the data section for pointers to data that can be
+// parsed as such a table.
+func (f *GoFile) EmbeddedFiles() ([]EmbeddedFile, error) {
+	types, err := f.GetTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the types needed to locate embedded files: %w", err)
+	}
+
+	// The file table is a slice of embed.file structures. If the type is not
+	// in the binary, no embed.FS is used.
+	var fileType *GoType
+	for _, t := range types {
+		if t.PackagePath == "embed" && t.Name == "embed.file" && t.Kind == reflect.Struct {
+			fileType = t
+			break
+		}
+	}
+	if fileType == nil || len(fileType.Fields) != 3 {
+		return nil, nil
+	}
+
+	md, err := f.Moduledata()
+	if err != nil {
+		return nil, err
+	}
+	data, err := md.Data().Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the data section: %w", err)
+	}
+
+	var files []EmbeddedFile
+	seen := make(map[uint64]bool)
+	r := bytes.NewReader(data)
+	is32 := f.FileInfo.WordSize == intSize32
+	for r.Len() >= f.FileInfo.WordSize {
+		// The embed.FS structure only has one field, a pointer to the slice
+		// of files.
+		ptr, err := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
+		if err != nil {
+			break
+		}
+		if ptr == 0 || seen[ptr] {
+			continue
+		}
+		seen[ptr] = true
+
+		fs, ok := f.readEmbedFileTable(ptr)
+		if !ok {
+			continue
+		}
+		files = append(files, fs...)
+	}
+
+	return files, nil
+}
+
+// readEmbedFileTable parses the slice of embed.file structures that the slice
+// header at the address points to. If the data isn't a valid file table, false
+// is returned.
+func (f *GoFile) readEmbedFileTable(addr uint64) ([]EmbeddedFile, bool) {
+	is32 := f.FileInfo.WordSize == intSize32
+	ws := uint64(f.FileInfo.WordSize)
+
+	hdr, err := f.Bytes(addr, 3*ws)
+	if err != nil {
+		return nil, false
+	}
+	r := bytes.NewReader(hdr)
+	tab, _ := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
+	n, _ := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
+	c, _ := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
+	if tab == 0 || n == 0 || n != c || n > maxEmbeddedFiles {
+		return nil, false
+	}
+
+	// The embed.file structure is defined as:
+	//	type file struct {
+	//		name string
+	//		data string
+	//		hash [16]byte
+	//	}
+	entrySize := 4*ws + 16
+	entries, err := f.Bytes(tab, n*entrySize)
+	if err != nil {
+		return nil, false
+	}
+
+	// inSection returns true if the data fits in the section it starts in.
+	// It rejects the lengths of random data before they are used.
+	inSection := func(ptr, length uint64) bool {
+		base, sect, err := f.fh.getSectionDataFromAddress(ptr)
+		return err == nil && ptr >= base && length <= uint64(len(sect))-(ptr-base)
+	}
+
+	files := make([]EmbeddedFile, 0, n)
+	r = bytes.NewReader(entries)
+	var prevDir, prevElem string
+	for i := uint64(0); i < n; i++ {
+		namePtr, _ := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
+		nameLen, _ := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
+		dataPtr, _ := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
+		dataLen, _ := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
+		// Skip the hash.
+		r.Seek(16, io.SeekCurrent)
+
+		if namePtr == 0 || nameLen == 0 || !inSection(namePtr, nameLen) {
+			return nil, false
+		}
+		name, err := f.Bytes(namePtr, nameLen)
+		if err != nil || !utf8.Valid(name) || bytes.IndexByte(name, 0) != -1 {
+			return nil, false
+		}
+
+		// The compiler sorts the files by directory and then by name, so
+		// a table that isn't sorted is not a file table.
+		dir, elem := embedSplitName(string(name))
+		if i > 0 && (dir < prevDir || dir == prevDir && elem <= prevElem) {
+			return nil, false
+		}
+		prevDir, prevElem = dir, elem
+
+		file := EmbeddedFile{Name: string(name)}
+		if dataLen != 0 {
+			if strings.HasSuffix(file.Name, "/") {
+				// Directories don't have any data.
+				return nil, false
+			}
+			if !inSection(dataPtr, dataLen) {
+				return nil, false
+			}
+			buf, err := f.Bytes(dataPtr, dataLen)
+			if err != nil {
+				return nil, false
+			}
+			file.Data = make([]byte, len(buf))
+			copy(file.Data, buf)
+		}
+		files = append(files, file)
+	}
+
+	return files, true
+}
+
+// embedSplitName splits the name into the directory and element parts the
+// same way as the embed package does when sorting the files.
+func embedSplitName(name string) (dir, elem string) {
+	name = strings.TrimSuffix(name, "/")
+	i := strings.LastIndexByte(name, '/')
+	if i < 0 {
+		return ".", name
+	}
+	return name[:i], name[i+1:]
+}
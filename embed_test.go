@@ -0,0 +1,129 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildEmbedTable creates a section with a slice header at the base address
+// pointing to an embed.file table for the files.
+func buildEmbedTable(base uint64, files []EmbeddedFile) []byte {
+	const entrySize = 4*8 + 16
+	strOff := uint64(3*8 + len(files)*entrySize)
+	var strs []byte
+
+	sect := make([]byte, strOff)
+	binary.LittleEndian.PutUint64(sect[0:], base+3*8)
+	binary.LittleEndian.PutUint64(sect[8:], uint64(len(files)))
+	binary.LittleEndian.PutUint64(sect[16:], uint64(len(files)))
+	for i, f := range files {
+		e := sect[3*8+i*entrySize:]
+		binary.LittleEndian.PutUint64(e[0:], base+strOff+uint64(len(strs)))
+		binary.LittleEndian.PutUint64(e[8:], uint64(len(f.Name)))
+		strs = append(strs, f.Name...)
+		if len(f.Data) != 0 {
+			binary.LittleEndian.PutUint64(e[16:], base+strOff+uint64(len(strs)))
+			binary.LittleEndian.PutUint64(e[24:], uint64(len(f.Data)))
+			strs = append(strs, f.Data...)
+		}
+	}
+	return append(sect, strs...)
+}
+
+func TestReadEmbedFileTable(t *testing.T) {
+	const base = uint64(0x1000)
+
+	tests := []struct {
+		name  string
+		files []EmbeddedFile
+		valid bool
+	}{
+		{"single file", []EmbeddedFile{{Name: "main.go", Data: []byte("package main")}}, true},
+		{"directory", []EmbeddedFile{
+			{Name: "static/"},
+			{Name: "static/a.txt", Data: []byte("a")},
+			{Name: "static/sub/"},
+			{Name: "static/z.txt", Data: []byte("z")},
+			{Name: "static/sub/c.txt", Data: []byte("c")},
+		}, true},
+		{"not sorted", []EmbeddedFile{{Name: "b"}, {Name: "a"}}, false},
+		{"directory with data", []EmbeddedFile{{Name: "dir/", Data: []byte("data")}}, false},
+		{"empty name", []EmbeddedFile{{Name: ""}}, false},
+		{"empty table", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sect := buildEmbedTable(base, test.files)
+			f := &GoFile{
+				FileInfo: &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64},
+				fh: &mockFileHandler{
+					mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+						if a < base || a >= base+uint64(len(sect)) {
+							return 0, nil, errors.New("out of bound")
+						}
+						return base, sect, nil
+					},
+				},
+			}
+
+			files, ok := f.readEmbedFileTable(base)
+			assert.Equal(t, test.valid, ok)
+			if test.valid {
+				assert.Equal(t, test.files, files)
+			}
+		})
+	}
+}
+
+func TestReadEmbedFileTableHugeLength(t *testing.T) {
+	const base = uint64(0x1000)
+
+	for _, field := range []struct {
+		name string
+		off  int
+	}{{"name", 8}, {"data", 24}} {
+		t.Run(field.name, func(t *testing.T) {
+			sect := buildEmbedTable(base, []EmbeddedFile{{Name: "main.go", Data: []byte("package main")}})
+			// Set the length of the first entry to a value that overflows
+			// the bounds check if added to the address.
+			binary.LittleEndian.PutUint64(sect[3*8+field.off:], ^uint64(0)-0x10)
+			f := &GoFile{
+				FileInfo: &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64},
+				fh: &mockFileHandler{
+					mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+						if a < base || a >= base+uint64(len(sect)) {
+							return 0, nil, errors.New("out of bound")
+						}
+						return base, sect, nil
+					},
+				},
+			}
+
+			assert.NotPanics(t, func() {
+				_, ok := f.readEmbedFileTable(base)
+				assert.False(t, ok)
+			})
+		})
+	}
+}
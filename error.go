@@ -35,4 +35,49 @@ var (
 	ErrInvalidGoVersion = errors.New("invalid go version")
 	// ErrNoGoRootFound is returned if no goroot was found in the binary.
 	ErrNoGoRootFound = errors.New("no goroot found")
+	// ErrFunctionNotFound is returned if the function can't be found in the PCLN table.
+	ErrFunctionNotFound = errors.New("function not found")
+	// ErrNoFuncData is returned if the function does not have the requested funcdata table.
+	ErrNoFuncData = errors.New("no funcdata for function")
+	// ErrNoPCData is returned if the function does not have the requested pcdata table.
+	ErrNoPCData = errors.New("no pcdata for function")
+	// ErrPCLNTabAlreadySet is returned if the PCLN table is set after it has already
+	// been located and used.
+	ErrPCLNTabAlreadySet = errors.New("pclntab has already been set")
+	// ErrNoMainFunction is returned if the binary does not have a main.main function.
+	// This is the case for binaries built with for example "-buildmode=c-shared".
+	ErrNoMainFunction = errors.New("no main function found")
+	// ErrNoMainPackage is returned if the packages can't be classified because the
+	// binary has neither a main package nor module information.
+	ErrNoMainPackage = errors.New("no main package found")
+	// ErrNoInitTasks is returned if the binary does not have the list of package
+	// initialization tasks. The list was added to the moduledata in Go 1.21.
+	ErrNoInitTasks = errors.New("no init tasks found")
+	// ErrUnknownModuledata is returned if a Moduledata value was not created by
+	// the library.
+	ErrUnknownModuledata = errors.New("unknown moduledata")
+	// ErrNoGoFuncData is returned if the binary does not have the "go:func.*" data.
+	// The data was added to the moduledata in Go 1.18.
+	ErrNoGoFuncData = errors.New("no go:func.* data found")
+	// ErrNotFuncValue is returned if an address is not a function value.
+	ErrNotFuncValue = errors.New("not a function value")
+	// ErrNotItab is returned if an address is not an itab.
+	ErrNotItab = errors.New("not an itab")
+	// ErrUnsupportedGoVersion is returned if the requested data can't be resolved
+	// for the Go version the binary was compiled with.
+	ErrUnsupportedGoVersion = errors.New("unsupported go version")
+	// ErrUnsupportedCompiler is returned if the binary was not compiled with the gc
+	// toolchain, for example by gccgo or TinyGo, so it doesn't have the runtime data.
+	ErrUnsupportedCompiler = errors.New("unsupported compiler")
+	// ErrLikelyPacked is returned if the runtime data can't be found and the binary
+	// looks like it has been packed, for example by UPX.
+	ErrLikelyPacked = errors.New("the binary is likely packed")
+	// ErrNoEntryPoint is returned if the entry point of the file can't be determined.
+	ErrNoEntryPoint = errors.New("no entry point found")
+	// ErrUnsupportedArch is returned if the code of the binary's architecture
+	// can't be disassembled.
+	ErrUnsupportedArch = errors.New("unsupported architecture")
+	// ErrNoCompilationUnits is returned if the PCLN table doesn't record the
+	// compilation units, which is the case before Go 1.16.
+	ErrNoCompilationUnits = errors.New("no compilation units available")
 )
@@ -19,15 +19,21 @@ package gore
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"debug/dwarf"
+	"debug/elf"
 	"debug/gosym"
+	"debug/pe"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"reflect"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/blacktop/go-macho"
@@ -42,6 +48,9 @@ var (
 	machoMagic2    = []byte{0xfe, 0xed, 0xfa, 0xcf}
 	machoMagic3    = []byte{0xce, 0xfa, 0xed, 0xfe}
 	machoMagic4    = []byte{0xcf, 0xfa, 0xed, 0xfe}
+	plan9Magic386  = []byte{0x00, 0x00, 0x01, 0xeb}
+	plan9MagicAMD  = []byte{0x00, 0x00, 0x8a, 0x97}
+	plan9MagicARM  = []byte{0x00, 0x00, 0x06, 0x47}
 )
 
 // Open opens a file and returns a handler to the file.
@@ -68,28 +77,42 @@ func OpenReader(f io.ReaderAt) (*GoFile, error) {
 	if n < maxMagicBufLen {
 		return nil, ErrNotEnoughBytesRead
 	}
-	gofile := new(GoFile)
+	var fh fileHandler
 	if fileMagicMatch(buf, elfMagic) {
 		elf, err := openELF(f)
 		if err != nil {
 			return nil, err
 		}
-		gofile.fh = elf
+		fh = elf
 	} else if fileMagicMatch(buf, peMagic) {
 		pe, err := openPE(f)
 		if err != nil {
 			return nil, err
 		}
-		gofile.fh = pe
+		fh = pe
 	} else if fileMagicMatch(buf, machoMagic1) || fileMagicMatch(buf, machoMagic2) || fileMagicMatch(buf, machoMagic3) || fileMagicMatch(buf, machoMagic4) {
 		machO, err := openMachO(f)
 		if err != nil {
 			return nil, err
 		}
-		gofile.fh = machO
+		fh = machO
+	} else if fileMagicMatch(buf, plan9Magic386) || fileMagicMatch(buf, plan9MagicAMD) || fileMagicMatch(buf, plan9MagicARM) {
+		plan9, err := openPlan9(f)
+		if err != nil {
+			return nil, err
+		}
+		fh = plan9
 	} else {
 		return nil, ErrUnsupportedFile
 	}
+
+	return newGoFile(fh), nil
+}
+
+// newGoFile creates a GoFile for the file handler and extracts the information
+// that is available without further analysis.
+func newGoFile(fh fileHandler) *GoFile {
+	gofile := &GoFile{fh: fh}
 	gofile.FileInfo = gofile.fh.getFileInfo()
 
 	// If the ID has been removed or tampered with, this will fail. If we can't
@@ -100,20 +123,43 @@ func OpenReader(f io.ReaderAt) (*GoFile, error) {
 	}
 
 	// Try to extract build information.
-	if bi, err := gofile.extractBuildInfo(); err == nil {
+	if bi, source, err := gofile.extractBuildInfo(); err == nil {
 		// This error is a minor failure; it just means we don't have
 		// this information.
 		// So if fails, we just ignore it.
 		gofile.BuildInfo = bi
 		if bi.Compiler != nil {
 			gofile.FileInfo.goversion = bi.Compiler
+			gofile.versionSource = source
 		}
+		gofile.FileInfo.OS = refineOS(gofile.FileInfo.OS, bi)
 	}
 
-	return gofile, nil
+	return gofile
+}
+
+// refineOS returns the OS of the file using the target OS recorded in the
+// build settings when the file format can't tell them apart. Illumos binaries
+// look like Solaris binaries.
+func refineOS(os string, bi *BuildInfo) string {
+	if os != "solaris" || bi.ModInfo == nil {
+		return os
+	}
+	for _, s := range bi.ModInfo.Settings {
+		if s.Key == "GOOS" && s.Value == "illumos" {
+			return s.Value
+		}
+	}
+	return os
 }
 
 // GoFile is a structure representing a go binary file.
+//
+// The data is parsed lazily the first time it's needed and then cached. The
+// methods are safe for concurrent use by multiple goroutines, except for the
+// methods that change how the file is parsed: SetGoVersion, SetByteOrder,
+// SetPCLNTab, SetStdPkgs and SetUnknownAsMain. They should be called before
+// the file is shared.
 type GoFile struct {
 	// BuildInfo holds the data from the buildinfo structure.
 	// This can be a nil because it's not always available.
@@ -123,7 +169,8 @@ type GoFile struct {
 	// BuildID is the Go build ID hash extracted from the binary.
 	BuildID string
 
-	fh fileHandler
+	fh     fileHandler
+	closer closeGuard
 
 	stdPkgs   []*Package
 	generated []*Package
@@ -135,8 +182,15 @@ type GoFile struct {
 
 	initPackagesOnce  sync.Once
 	initPackagesError error
+	// stdPkgNames replaces the standard library package set when packages
+	// are classified, if set.
+	stdPkgNames map[string]struct{}
+	// unknownAsMain is true if the packages that can't be classified are
+	// returned as main packages.
+	unknownAsMain bool
 
 	runtimeText  uint64
+	runtimeEtext uint64
 	pclntabAddr  uint64
 	pclntabBytes []byte
 	pclntabOnce  sync.Once
@@ -144,20 +198,32 @@ type GoFile struct {
 
 	moduledata moduledata
 
-	versionError error
+	versionOnce   sync.Once
+	versionError  error
+	versionSource string
 
 	initModuleDataOnce  sync.Once
 	initModuleDataError error
+
+	gccgoOnce sync.Once
+	gccgo     bool
+
+	tinygoOnce sync.Once
+	tinygo     bool
+
+	packedOnce sync.Once
+	packed     bool
 }
 
 func (f *GoFile) initModuleData() error {
 	f.initModuleDataOnce.Do(func() {
 		err := f.ensureCompilerVersion()
 		if err != nil {
-			f.initModuleDataError = err
+			f.initModuleDataError = f.compilerError(err)
 			return
 		}
-		f.moduledata, f.initModuleDataError = extractModuledata(f)
+		f.moduledata, err = extractModuledata(f)
+		f.initModuleDataError = f.compilerError(err)
 	})
 	return f.initModuleDataError
 }
@@ -171,6 +237,18 @@ func (f *GoFile) Moduledata() (Moduledata, error) {
 	return f.moduledata, nil
 }
 
+// RawModuledata returns the address and the raw bytes of the moduledata
+// structure in the binary. The length of the data is the size of the structure
+// for the compiler version, so it can be compared with the expected layout
+// when the parsed moduledata looks wrong.
+func (f *GoFile) RawModuledata() (addr uint64, data []byte, err error) {
+	err = f.initModuleData()
+	if err != nil {
+		return 0, nil, err
+	}
+	return f.moduledata.rawAddr, bytes.Clone(f.moduledata.raw), nil
+}
+
 func (f *GoFile) initPackages() error {
 	f.initPackagesOnce.Do(func() {
 		tab, err := f.PCLNTab()
@@ -210,24 +288,35 @@ func (f *GoFile) GetCompilerVersion() (*GoVersion, error) {
 	return f.FileInfo.goversion, nil
 }
 
-func (f *GoFile) ensureCompilerVersion() error {
-	if f.FileInfo.goversion == nil {
-		f.tryExtractCompilerVersion()
+// CompilerVersionSource returns how the compiler version returned by
+// GetCompilerVersion was determined. The returned value is one of the
+// VersionSource constants, for example VersionSourceBuildInfo if the
+// version was read from the buildinfo structure.
+func (f *GoFile) CompilerVersionSource() (string, error) {
+	err := f.ensureCompilerVersion()
+	if err != nil {
+		return "", err
 	}
+	return f.versionSource, nil
+}
+
+func (f *GoFile) ensureCompilerVersion() error {
+	f.versionOnce.Do(f.tryExtractCompilerVersion)
 	return f.versionError
 }
 
-// tryExtractCompilerVersion tries to extract the compiler version from the binary.
-// should only be called if FileInfo.goversion is nil.
+// tryExtractCompilerVersion tries to extract the compiler version from the binary
+// if it's not already known. It's only called once, by ensureCompilerVersion.
 func (f *GoFile) tryExtractCompilerVersion() {
 	if f.FileInfo.goversion != nil {
 		return
 	}
-	v, err := findGoCompilerVersion(f)
+	v, src, err := findGoCompilerVersion(f)
 	if err != nil {
 		f.versionError = err
 	} else {
 		f.FileInfo.goversion = v
+		f.versionSource = src
 	}
 }
 
@@ -239,7 +328,223 @@ func (f *GoFile) SourceInfo(fn *Function) (string, int, int) {
 	return srcFile, start, end
 }
 
-// GetGoRoot returns the Go Root path used to compile the binary.
+// SourceInfoFast returns the source code filename and the starting line
+// number for the function. Unlike SourceInfo, it doesn't walk the function to
+// find the ending line number, so it's cheaper when listing many functions.
+// The PCLN table is loaded on the first call, and the error is returned if it
+// can't be.
+func (f *GoFile) SourceInfoFast(fn *Function) (string, int, error) {
+	if err := f.initPackages(); err != nil {
+		return "", 0, err
+	}
+	srcFile, start, _ := f.pclntab.PCToLine(fn.Offset)
+	return srcFile, start, nil
+}
+
+// RelativeSourcePath returns the path of the function's source file without
+// the machine specific prefix. Files in the Go root are returned relative to
+// the Go root, for example "src/runtime/proc.go". Files in the module cache
+// and in the main module are returned prefixed with the module path, the way
+// they are recorded in binaries built with the "-trimpath" flag, for example
+// "github.com/foo/bar@v1.2.3/baz.go". The main module's folder is derived
+// from the build information, so files in the main module are only trimmed
+// if the binary has it. If no prefix can be removed, the path is returned as
+// it is. If the function has no source file, ErrFunctionNotFound is returned.
+func (f *GoFile) RelativeSourcePath(fn *Function) (string, error) {
+	file := fn.Filename
+	if file == "" {
+		tab, err := f.PCLNTab()
+		if err != nil {
+			return "", err
+		}
+		file, _, _ = tab.PCToLine(fn.Offset)
+		if file == "" {
+			return "", ErrFunctionNotFound
+		}
+	}
+
+	// The Go root can't be found for binaries built with "-trimpath", but the
+	// paths are already relative.
+	if goroot, err := f.GetGoRoot(); err == nil && goroot != "" {
+		for _, sep := range []string{"/", `\`} {
+			if strings.HasPrefix(file, goroot+sep) {
+				return file[len(goroot)+1:], nil
+			}
+		}
+	}
+
+	if i := strings.Index(file, "/pkg/mod/"); i != -1 {
+		return file[i+len("/pkg/mod/"):], nil
+	}
+
+	if f.BuildInfo != nil && f.BuildInfo.ModInfo != nil {
+		info := f.BuildInfo.ModInfo
+		// The symbols of the main package use the package name "main", so
+		// the import path of the main package is used instead.
+		pkg := fn.PackageName
+		if pkg == "main" {
+			pkg = info.Path
+		}
+		if mod := info.Main.Path; mod != "" && (pkg == mod || strings.HasPrefix(pkg, mod+"/")) {
+			// The package's folder ends with the package's path within the
+			// module, the rest is the module's folder.
+			dir := strings.ReplaceAll(sourceDir(file), `\`, "/")
+			if rel := strings.TrimPrefix(pkg, mod); strings.HasSuffix(dir, rel) {
+				root := dir[:len(dir)-len(rel)]
+				if root != "" {
+					return mod + strings.ReplaceAll(file, `\`, "/")[len(root):], nil
+				}
+			}
+		}
+	}
+	return file, nil
+}
+
+// SourceFiles returns the paths of all the source files referenced in the PCLN
+// table. The paths are sorted and have no duplicates. Pseudo file names used by
+// the compiler for generated code, for example "<autogenerated>", are not
+// included.
+func (f *GoFile) SourceFiles() ([]string, error) {
+	tab, err := f.PCLNTab()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(tab.Files))
+	for file := range tab.Files {
+		if file == "" || strings.HasPrefix(file, "<") {
+			continue
+		}
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// CompilationUnit is a compilation unit recorded in the PCLN table. The
+// compiler produces one compilation unit for each package, so the files of
+// a unit are usually the source files of a package.
+type CompilationUnit struct {
+	// Files holds the paths of the source files in the compilation unit.
+	Files []string
+}
+
+// CompilationUnits returns the compilation units recorded in the PCLN table,
+// in the order they are stored in the table. The PCLN table only records the
+// compilation units for binaries compiled with Go 1.16 and later, for older
+// binaries ErrNoCompilationUnits is returned.
+func (f *GoFile) CompilationUnits() ([]CompilationUnit, error) {
+	t, err := f.getPCLNTable()
+	if err != nil {
+		return nil, err
+	}
+	units, err := t.compilationUnits()
+	if err != nil {
+		return nil, err
+	}
+	cus := make([]CompilationUnit, 0, len(units))
+	for _, files := range units {
+		cus = append(cus, CompilationUnit{Files: files})
+	}
+	return cus, nil
+}
+
+// LocalPaths returns the directories on the build machine that the source files
+// were located in, for example the project's root folder. Source files from the
+// Go root are excluded and files in the module cache are reduced to the cache's
+// folder. A folder located within another returned folder is not included. The
+// paths are sorted. If the binary was built with the "-trimpath" flag, no paths
+// are returned.
+func (f *GoFile) LocalPaths() ([]string, error) {
+	files, err := f.SourceFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	// The Go root may not be found, for example if the paths have been trimmed.
+	goroot, _ := f.GetGoRoot()
+
+	return localPaths(files, goroot), nil
+}
+
+// localPaths returns the top most folders of the source files that are not
+// located in the Go root.
+func localPaths(files []string, goroot string) []string {
+	dirs := make(map[string]struct{})
+	for _, file := range files {
+		if !isAbsPath(file) {
+			continue
+		}
+		if goroot != "" && strings.HasPrefix(file, goroot+"/") {
+			continue
+		}
+		dir := path.Dir(file)
+		if i := strings.Index(dir, "/pkg/mod/"); i != -1 {
+			dir = dir[:i+len("/pkg/mod")]
+		}
+		dirs[dir] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	sort.Strings(sorted)
+
+	var paths []string
+	for _, dir := range sorted {
+		sub := false
+		for _, p := range paths {
+			if strings.HasPrefix(dir, p+"/") {
+				sub = true
+				break
+			}
+		}
+		if !sub {
+			paths = append(paths, dir)
+		}
+	}
+	return paths
+}
+
+// isAbsPath returns true if the path is an absolute path on either a Unix
+// like system or Windows. The compiler uses forward slashes on all systems.
+func isAbsPath(p string) bool {
+	if strings.HasPrefix(p, "/") {
+		return true
+	}
+	return len(p) > 2 && p[1] == ':' && (p[2] == '/' || p[2] == '\\')
+}
+
+// sourceDir returns the directory of a source file path recorded in the
+// binary. The path style is detected from the path instead of using the
+// host's path handling, since a binary built on Windows can have paths with
+// backslashes as the separator. The separator used in the path is kept.
+func sourceDir(p string) string {
+	if !strings.Contains(p, `\`) {
+		return volumeRoot(path.Dir(p), "/")
+	}
+	dir := strings.ReplaceAll(path.Dir(strings.ReplaceAll(p, `\`, "/")), "/", `\`)
+	// Cleaning the path removes the leading backslash of a UNC path.
+	if strings.HasPrefix(p, `\\`) {
+		dir = `\` + dir
+	}
+	return volumeRoot(dir, `\`)
+}
+
+// volumeRoot adds the separator to a bare Windows drive letter, like "C:",
+// so the directory of a file in the root of the drive is the root.
+func volumeRoot(dir, sep string) string {
+	if len(dir) == 2 && dir[1] == ':' {
+		return dir + sep
+	}
+	return dir
+}
+
+// GetGoRoot returns the Go Root path used to compile the binary. If the path
+// isn't found, ErrNoGoRootFound is returned. If the path isn't found and the
+// code of the binary's architecture can't be disassembled to search for it,
+// ErrUnsupportedArch is returned instead.
 func (f *GoFile) GetGoRoot() (string, error) {
 	err := f.initPackages()
 	if err != nil {
@@ -254,16 +559,139 @@ func (f *GoFile) GetGoRoot() (string, error) {
 // normally extracted from the binary. For example, to set the version to
 // go 1.12.0, use "go1.12". For 1.7.2, use "go1.7.2".
 // If an incorrect version string or version not known to the library,
-// ErrInvalidGoVersion is returned.
+// ErrInvalidGoVersion is returned. The moduledata and the packages already
+// extracted with the previous version are discarded so they're extracted
+// again with the new version. The pclntab is kept, unless locating it failed,
+// since its base address may have been resolved from the moduledata.
+// SetGoVersion must not be called concurrently with other methods.
 func (f *GoFile) SetGoVersion(version string) error {
 	gv := ResolveGoVersion(version)
 	if gv == nil {
 		return ErrInvalidGoVersion
 	}
+	// The version is known, so it doesn't have to be extracted.
+	f.versionOnce.Do(func() {})
 	f.FileInfo.goversion = gv
+	f.versionSource = VersionSourceUser
+	// An error from a previous attempt to extract the version no longer applies.
+	f.versionError = nil
+
+	// The layout of the moduledata depends on the version.
+	if f.pclntabError != nil {
+		f.resetPclntab()
+	}
+	f.resetModuleData()
+	f.resetPackages()
 	return nil
 }
 
+// SetByteOrder overrides the byte order detected from the file's header. This
+// is an escape hatch for files where the header doesn't match the byte order
+// of the data, for example some raw dumps. The cached pclntab, moduledata,
+// packages and compiler version are discarded so they are parsed again with the
+// new byte order, including a pclntab set with SetPCLNTab. A compiler version set
+// with SetGoVersion or read from the buildinfo is kept. The file format handlers still use
+// the header's byte order when they search for the pclntab, so SetPCLNTab may
+// be needed as well. SetByteOrder must not be called concurrently with other
+// methods.
+func (f *GoFile) SetByteOrder(order binary.ByteOrder) {
+	f.FileInfo.ByteOrder = order
+	if _, ok := f.fh.(*byteOrderHandler); !ok {
+		f.fh = &byteOrderHandler{fileHandler: f.fh, fileInfo: f.FileInfo}
+	}
+	f.resetCaches()
+}
+
+// SetMaxTypeDepth sets how deep nested types, such as the element type of a
+// pointer or the types of a struct's fields, are followed when the types are
+// parsed. A type nested deeper is not parsed and has Truncated set. This guards
+// against type data crafted to exhaust the stack. The default limit is 1024,
+// which is far deeper than types found in real programs. A depth of 0 or less
+// restores the default. SetMaxTypeDepth must be called before the types are
+// parsed and must not be called concurrently with other methods.
+func (f *GoFile) SetMaxTypeDepth(depth int) {
+	f.FileInfo.maxTypeDepth = max(depth, 0)
+}
+
+// SetStdPkgs sets the names of the packages that are classified as the
+// standard library, instead of the package set of the mainline Go
+// distribution. This is useful for binaries built with a toolchain that ships
+// a different standard library, for example TinyGo or a forked toolchain. A
+// nil set restores the default. The packages are classified again the next
+// time they are used. SetStdPkgs must not be called concurrently with other
+// methods.
+func (f *GoFile) SetStdPkgs(pkgs map[string]struct{}) {
+	f.stdPkgNames = nil
+	if pkgs != nil {
+		f.stdPkgNames = make(map[string]struct{}, len(pkgs))
+		for p := range pkgs {
+			f.stdPkgNames[p] = struct{}{}
+		}
+	}
+	f.resetPackages()
+}
+
+// SetUnknownAsMain sets whether the packages that can't be classified are
+// returned by GetPackages as part of the main project instead of by
+// GetUnknown. The packages that aren't from the standard library or a vendor
+// are usually the main project's packages with paths that can't be matched
+// to the main package, for example in binaries built with the "-trimpath"
+// flag. The packages are classified again the next time they are used.
+// SetUnknownAsMain must not be called concurrently with other methods.
+func (f *GoFile) SetUnknownAsMain(enabled bool) {
+	f.unknownAsMain = enabled
+	f.resetPackages()
+}
+
+// resetCaches discards the lazily initialized data so it's parsed again the
+// next time it's used.
+func (f *GoFile) resetCaches() {
+	f.resetPclntab()
+	f.resetModuleData()
+	f.resetPackages()
+
+	// A version set with SetGoVersion or read from the buildinfo doesn't depend
+	// on the discarded data. Other versions are extracted again.
+	if f.versionSource != VersionSourceUser && f.versionSource != VersionSourceBuildInfo {
+		f.versionOnce = sync.Once{}
+		f.versionError = nil
+		f.FileInfo.goversion = nil
+		f.versionSource = ""
+	}
+}
+
+// resetPclntab discards the located pclntab so it's located again the next
+// time it's used.
+func (f *GoFile) resetPclntab() {
+	f.pclntabOnce = sync.Once{}
+	f.pclntab = nil
+	f.pclntabAddr = 0
+	f.pclntabBytes = nil
+	f.pclntabError = nil
+	f.runtimeText = 0
+	f.runtimeEtext = 0
+}
+
+// resetModuleData discards the extracted moduledata so it's extracted again
+// the next time it's used.
+func (f *GoFile) resetModuleData() {
+	f.initModuleDataOnce = sync.Once{}
+	f.moduledata = moduledata{}
+	f.initModuleDataError = nil
+}
+
+// resetPackages discards the enumerated packages so they're classified again
+// the next time they're used.
+func (f *GoFile) resetPackages() {
+	f.initPackagesOnce = sync.Once{}
+	f.initPackagesError = nil
+	f.pkgs = nil
+	f.vendors = nil
+	f.stdPkgs = nil
+	f.generated = nil
+	f.unknown = nil
+}
+
 // GetPackages returns the go packages that have been classified as part of the main
 // project.
 func (f *GoFile) GetPackages() ([]*Package, error) {
@@ -296,10 +724,28 @@ func (f *GoFile) GetUnknown() ([]*Package, error) {
 	return f.unknown, err
 }
 
+// allPackages returns the packages from all the package classes.
+func (f *GoFile) allPackages() ([]*Package, error) {
+	err := f.initPackages()
+	if err != nil {
+		return nil, err
+	}
+	pkgs := make([]*Package, 0, len(f.pkgs)+len(f.vendors)+len(f.stdPkgs)+len(f.generated)+len(f.unknown))
+	pkgs = append(pkgs, f.pkgs...)
+	pkgs = append(pkgs, f.vendors...)
+	pkgs = append(pkgs, f.stdPkgs...)
+	pkgs = append(pkgs, f.generated...)
+	pkgs = append(pkgs, f.unknown...)
+	return pkgs, nil
+}
+
 func (f *GoFile) enumPackages() error {
 	tab := f.pclntab
 	packages := make(map[string]*Package)
 	allPackages := sort.StringSlice{}
+	// The raw table is used to look up the file when the gosym package
+	// fails to resolve it.
+	raw, _ := newPCLNTable(f.pclntabBytes, f.pclntabAddr, f.runtimeText, f.FileInfo.ByteOrder)
 
 	for _, n := range tab.Funcs {
 		p, ok := packages[n.PackageName()]
@@ -313,30 +759,32 @@ func (f *GoFile) enumPackages() error {
 			allPackages = append(allPackages, n.PackageName())
 		}
 
+		fp, line, _ := tab.PCToLine(n.Entry)
+		if fp == "" && raw != nil {
+			fp = raw.funcFile(n.Entry)
+		}
+		fn := &Function{
+			Name:         n.BaseName(),
+			Offset:       n.Entry,
+			End:          n.End,
+			PackageName:  n.PackageName(),
+			Filename:     fp,
+			SrcLineStart: line,
+			symbolName:   n.Name,
+		}
+
 		if n.ReceiverName() != "" {
 			m := &Method{
-				Function: &Function{
-					Name:        n.BaseName(),
-					Offset:      n.Entry,
-					End:         n.End,
-					PackageName: n.PackageName(),
-				},
+				Function: fn,
 				Receiver: n.ReceiverName(),
 			}
 
 			p.Methods = append(p.Methods, m)
 		} else {
-			f := &Function{
-				Name:        n.BaseName(),
-				Offset:      n.Entry,
-				End:         n.End,
-				PackageName: n.PackageName(),
-			}
-			p.Functions = append(p.Functions, f)
+			p.Functions = append(p.Functions, fn)
 		}
 
 		if p.Filepath == "" {
-			fp, _, _ := tab.PCToLine(n.Entry)
 			switch fp {
 			case "<autogenerated>", "":
 				pkg := n.PackageName()
@@ -344,7 +792,7 @@ func (f *GoFile) enumPackages() error {
 					p.Filepath = fp
 				}
 			default:
-				p.Filepath = path.Dir(fp)
+				p.Filepath = sourceDir(fp)
 			}
 		}
 	}
@@ -353,15 +801,32 @@ func (f *GoFile) enumPackages() error {
 
 	var classifier PackageClassifier
 
+	var mainModule string
 	if f.BuildInfo != nil && f.BuildInfo.ModInfo != nil {
-		classifier = NewModPackageClassifier(f.BuildInfo.ModInfo)
+		mainModule = f.BuildInfo.ModInfo.Main.Path
+	}
+	mainPkg, hasMain := packages["main"]
+
+	// Binaries built with "-trimpath" record the main module's packages
+	// by their import path, so the path classifier can place them if it
+	// knows the main module.
+	if f.BuildInfo != nil && f.BuildInfo.ModInfo != nil &&
+		!(hasMain && mainModule != "" && isTrimmedPath(mainPkg.Filepath)) {
+		c := NewModPackageClassifier(f.BuildInfo.ModInfo)
+		c.stdPkgs = f.stdPkgNames
+		classifier = c
 	} else {
-		mainPkg, ok := packages["main"]
-		if !ok {
-			return fmt.Errorf("no main package found")
+		if !hasMain {
+			return ErrNoMainPackage
 		}
 
-		classifier = NewPathPackageClassifier(mainPkg.Filepath)
+		c := NewPathPackageClassifier(mainPkg.Filepath)
+		c.mainModule = mainModule
+		c.stdPkgs = f.stdPkgNames
+		classifier = c
+	}
+	if f.unknownAsMain {
+		classifier = NewUnknownAsMainClassifier(classifier)
 	}
 
 	for n, p := range packages {
@@ -383,9 +848,17 @@ func (f *GoFile) enumPackages() error {
 	return nil
 }
 
-// Close releases the file handler.
+// Close releases the file handler. It is safe to call Close more than once,
+// calls after the first return nil.
 func (f *GoFile) Close() error {
-	return f.fh.Close()
+	return f.closer.close(f.fh.Close)
+}
+
+// HasSymbolTable returns true if the file has a symbol table with at least one
+// symbol. If it doesn't, for example because the binary was linked with the "-s"
+// flag, GetSymbol always fails and the data is located with heuristics instead.
+func (f *GoFile) HasSymbolTable() bool {
+	return f.fh.hasSymbolTable()
 }
 
 // GetSymbol returns the symbol with the given name.
@@ -420,62 +893,220 @@ func (f *GoFile) initPclntab() error {
 		if err != nil {
 			addr, data, err = f.fh.getPCLNTABData()
 			if err != nil {
-				f.pclntabError = fmt.Errorf("error when getting pclntab: %w", err)
+				f.pclntabError = f.compilerError(fmt.Errorf("error when getting pclntab: %w", err))
 				return
 			}
 		}
 		f.pclntabAddr = addr
 		f.pclntabBytes = data
+		f.pclntabError = f.resolveRuntimeText()
+	})
+	return f.pclntabError
+}
 
-		// All the function address in the pclntab uses the symbol "runtime.text" as the base address.
-		// This symbol is where the runtime uses as the start of the code section. While it should always
-		// be located within the binary's text section, it may not be at the start of the section. For example,
-		// external linkers may add additional code to the section before the "Go" code. We can find "runtime.text"
-		// in the moduledata structure in the binary.
-		// If we have the symbol table, just get it
-		sym, err := f.fh.getSymbol("runtime.text")
-		if err == nil {
-			f.runtimeText = sym.Value
-			return
-		}
+// IsGccGo returns true if the binary was compiled with gccgo instead of the gc
+// toolchain. Binaries compiled by gccgo don't have the PCLN table and the
+// moduledata, so the getters that need them return ErrUnsupportedCompiler. The
+// compiler is detected by the ".go_export" section, a dependency on the libgo
+// runtime library or the symbols gccgo uses for package initialization.
+func (f *GoFile) IsGccGo() bool {
+	f.gccgoOnce.Do(func() {
+		f.gccgo = isGccGo(f.fh)
+	})
+	return f.gccgo
+}
 
-		// Otherwise, we need to search it
-		_, moddataSection, err := f.fh.getSectionData(f.fh.moduledataSection())
-		if err != nil {
-			f.pclntabError = fmt.Errorf("failed to get the section %s where the moduledata structure is stored: %w", f.fh.moduledataSection(), err)
-			return
+// isGccGo checks the file for the traces left by gccgo.
+func isGccGo(fh fileHandler) bool {
+	if _, _, err := fh.getSectionData(".go_export"); err == nil {
+		return true
+	}
+	if ef, ok := fh.getParsedFile().(*elf.File); ok {
+		libs, _ := ef.ImportedLibraries()
+		for _, lib := range libs {
+			if strings.HasPrefix(lib, "libgo.so") {
+				return true
+			}
 		}
+	}
+	// Older versions name the package initialization function "__go_init_main",
+	// later versions "main..import".
+	for _, name := range []string{"__go_init_main", "main..import"} {
+		if _, err := fh.getSymbol(name); err == nil {
+			return true
+		}
+	}
+	return false
+}
 
-		// At this point, we don't know what compiler version was used so we can't parse the moduledata structure.
-		// We do know the field in different structure versions so we can check these offsets and see if the fall
-		// within the text section.
-		textStart, textData, err := f.fh.getCodeSection()
-		if err != nil {
-			f.pclntabError = fmt.Errorf("failed to get the file's text section: %w", err)
-			return
+// IsTinyGo returns true if the binary was compiled with TinyGo instead of the
+// gc toolchain. TinyGo uses LLVM and its own runtime, so the binaries don't
+// have the PCLN table and the moduledata and the getters that need them return
+// ErrUnsupportedCompiler. The compiler is detected by the symbols of the
+// assembly helpers in TinyGo's runtime.
+func (f *GoFile) IsTinyGo() bool {
+	f.tinygoOnce.Do(func() {
+		f.tinygo = isTinyGo(f.fh)
+	})
+	return f.tinygo
+}
+
+// isTinyGo checks the file for the symbols only found in the TinyGo runtime.
+func isTinyGo(fh fileHandler) bool {
+	// The stack scanning helpers are used by the garbage collector on all
+	// targets, "runtime.initAll" runs the package initializers.
+	for _, name := range []string{"tinygo_scanCurrentStack", "tinygo_scanstack", "runtime.initAll"} {
+		if _, err := fh.getSymbol(name); err == nil {
+			return true
 		}
+	}
+	return false
+}
 
-		// Since the moduledata starts with the address to the pclntab, we can use this to find the moduledata structure.
-		runtimeText, err := f.findRuntimeText(textStart, textStart+uint64(len(textData)), f.pclntabAddr, moddataSection)
-		if err != nil {
-			if f.FileInfo.OS == "macOS" && f.FileInfo.Arch == ArchARM64 {
-				t, err := f.findRuntimeTextMachoChainedFixups(f.pclntabAddr)
-				if err != nil {
-					f.pclntabError = fmt.Errorf("failed to find runtime.text symbol: %w", err)
-					return
-				}
-				f.runtimeText = t
-				return
-			}
+// compilerError returns ErrUnsupportedCompiler instead of the error if the
+// binary was compiled with gccgo or TinyGo, since the error is caused by the missing
+// runtime data. If the binary looks packed, ErrLikelyPacked is returned
+// instead.
+func (f *GoFile) compilerError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if f.IsGccGo() {
+		return fmt.Errorf("%w: the binary was compiled with gccgo", ErrUnsupportedCompiler)
+	}
+	if f.IsTinyGo() {
+		return fmt.Errorf("%w: the binary was compiled with TinyGo", ErrUnsupportedCompiler)
+	}
+	if f.IsPacked() {
+		return fmt.Errorf("%w: %w", ErrLikelyPacked, err)
+	}
+	return err
+}
 
-			f.pclntabError = fmt.Errorf("failed to find runtime.text symbol: %w", err)
-			return
+// packedEntropy is the entropy of the code section, in bits per byte, above
+// which the code is assumed to be compressed or encrypted. Uncompressed
+// machine code is usually well below 7 bits per byte.
+const packedEntropy = 7.2
+
+// upxMagic is the magic UPX writes to the headers of the packed file.
+var upxMagic = []byte("UPX!")
+
+// IsPacked returns true if the binary looks like it has been packed, for
+// example by UPX. A packed binary has the Go runtime data compressed so it
+// can't be analyzed until it has been unpacked. The file is checked for the
+// sections and the magic written by UPX, and for a code section with a high
+// entropy. A missing code section alone is not taken as a sign of packing. The
+// getters that fail to locate the runtime data of a packed binary return
+// ErrLikelyPacked.
+func (f *GoFile) IsPacked() bool {
+	f.packedOnce.Do(func() {
+		f.packed = isPacked(f.fh)
+	})
+	return f.packed
+}
+
+// isPacked checks the file for the traces left by a packer.
+func isPacked(fh fileHandler) bool {
+	for _, name := range []string{"UPX0", "UPX1", ".upx0", ".upx1"} {
+		if _, _, err := fh.getSectionData(name); err == nil {
+			return true
 		}
-		f.runtimeText = runtimeText
+	}
+	// UPX writes its magic close to the start of the file.
+	buf := make([]byte, 4096)
+	n, _ := fh.getReader().ReadAt(buf, 0)
+	if bytes.Contains(buf[:n], upxMagic) {
+		return true
+	}
+	_, code, err := fh.getCodeSection()
+	if err != nil || len(code) == 0 {
+		return false
+	}
+	return entropy(code) > packedEntropy
+}
+
+// SetPCLNTab sets the location and the data of the PCLN table. This can be used
+// if gore is not able to locate the table, for example in a binary that has
+// been tampered with, but it has been found by other means. The address is the
+// virtual address of the table's start and the data should start with the table's
+// header. The table can only be set before it has been used, otherwise
+// ErrPCLNTabAlreadySet is returned.
+func (f *GoFile) SetPCLNTab(addr uint64, data []byte) error {
+	if _, err := newPCLNTable(data, addr, 0, f.FileInfo.ByteOrder); err != nil {
+		return fmt.Errorf("invalid pclntab: %w", err)
+	}
+	set := false
+	f.pclntabOnce.Do(func() {
+		set = true
+		f.pclntabAddr = addr
+		f.pclntabBytes = data
+		f.pclntabError = f.resolveRuntimeText()
 	})
+	if !set {
+		return ErrPCLNTabAlreadySet
+	}
 	return f.pclntabError
 }
 
+// resolveRuntimeText sets the address of the "runtime.text" symbol. The address
+// of the pclntab must be known. If the address of the "runtime.etext" symbol is
+// found at the same time, it is also set.
+func (f *GoFile) resolveRuntimeText() error {
+	// All the function address in the pclntab uses the symbol "runtime.text" as the base address.
+	// This symbol is where the runtime uses as the start of the code section. While it should always
+	// be located within the binary's text section, it may not be at the start of the section. For example,
+	// external linkers may add additional code to the section before the "Go" code. We can find "runtime.text"
+	// in the moduledata structure in the binary.
+	// If we have the symbol table, just get it
+	sym, err := f.fh.getSymbol("runtime.text")
+	if err == nil {
+		f.runtimeText = sym.Value
+		if sym, err = f.fh.getSymbol("runtime.etext"); err == nil {
+			f.runtimeEtext = sym.Value
+		}
+		return nil
+	}
+
+	// Otherwise, we need to search it
+	_, moddataSection, err := f.fh.getSectionData(f.fh.moduledataSection())
+	if err != nil {
+		return fmt.Errorf("failed to get the section %s where the moduledata structure is stored: %w", f.fh.moduledataSection(), err)
+	}
+
+	// At this point, we don't know what compiler version was used so we can't parse the moduledata structure.
+	// We do know the field in different structure versions so we can check these offsets and see if the fall
+	// within the text section.
+	textStart, textData, err := f.fh.getCodeSection()
+	if err != nil {
+		return fmt.Errorf("failed to get the file's text section: %w", err)
+	}
+
+	// Since the moduledata starts with the address to the pclntab, we can use this to find the moduledata structure.
+	runtimeText, runtimeEtext, err := f.findRuntimeText(textStart, textStart+uint64(len(textData)), f.pclntabAddr, moddataSection)
+	if err != nil && f.FileInfo.OS == "windows" {
+		// Externally linked binaries, for example when cgo is used, are linked
+		// by the C toolchain which may not place the moduledata in the ".data"
+		// section. The other data sections are searched as a fallback.
+		runtimeText, runtimeEtext, err = f.findRuntimeTextPE(textStart, textStart+uint64(len(textData)), f.pclntabAddr)
+	}
+	if err != nil {
+		if f.FileInfo.OS == "macOS" && f.FileInfo.Arch == ArchARM64 {
+			t, et, err := f.findRuntimeTextMachoChainedFixups(f.pclntabAddr)
+			if err != nil {
+				return fmt.Errorf("failed to find runtime.text symbol: %w", err)
+			}
+			f.runtimeText = t
+			f.runtimeEtext = et
+			return nil
+		}
+
+		return fmt.Errorf("failed to find runtime.text symbol: %w", err)
+	}
+	f.runtimeText = runtimeText
+	f.runtimeEtext = runtimeEtext
+	return nil
+}
+
 // PCLNTab returns the PCLN table.
 func (f *GoFile) PCLNTab() (*gosym.Table, error) {
 	err := f.initPclntab()
@@ -485,11 +1116,208 @@ func (f *GoFile) PCLNTab() (*gosym.Table, error) {
 	return gosym.NewTable(make([]byte, 0), gosym.NewLineTable(f.pclntabBytes, f.runtimeText))
 }
 
-func (f *GoFile) findRuntimeTextMachoChainedFixups(pclntabAddr uint64) (uint64, error) {
+// PCLNTabCandidates returns the addresses of all the PCLN tables found in the
+// binary. A binary can hold more than one table, for example if it embeds
+// another binary, and the table picked by PCLNTab may not be the right one.
+// The addresses are returned in the order the tables are tried, so the first
+// is the table used by PCLNTab unless it's replaced with SetPCLNTab. The table
+// located by the "runtime.pclntab" symbol comes first, followed by the tables
+// found by searching the sections that can hold a table. If no table is found,
+// ErrNoPCLNTab is returned.
+func (f *GoFile) PCLNTabCandidates() ([]uint64, error) {
+	var candidates []uint64
+	if addr, _, err := f.getPCLNTABDataBySymbol(); err == nil {
+		candidates = append(candidates, addr)
+	}
+	candidates = append(candidates, f.fh.getPCLNTABCandidates()...)
+
+	seen := make(map[uint64]struct{}, len(candidates))
+	addrs := candidates[:0]
+	for _, addr := range candidates {
+		if _, ok := seen[addr]; ok {
+			continue
+		}
+		seen[addr] = struct{}{}
+		addrs = append(addrs, addr)
+	}
+	if len(addrs) == 0 {
+		return nil, ErrNoPCLNTab
+	}
+	return addrs, nil
+}
+
+// PCLNTabVersion returns the version of the PCLN table's format, for example "1.18".
+// The version is the Go version that introduced the format, so a binary compiled
+// with Go 1.19 has the version "1.18". This can be used to cross-check the compiler
+// version, a mismatch indicates that the binary has been tampered with or that the
+// compiler version has been misidentified.
+func (f *GoFile) PCLNTabVersion() (string, error) {
+	err := f.initPclntab()
+	if err != nil {
+		return "", err
+	}
+	if len(f.pclntabBytes) < 4 {
+		return "", ErrNoPCLNTab
+	}
+	v, ok := pclntabVersions[f.FileInfo.ByteOrder.Uint32(f.pclntabBytes)]
+	if !ok {
+		return "", ErrNoPCLNTab
+	}
+	return v, nil
+}
+
+// PCLNTabSection returns the name of the section the PCLN table is located in,
+// for example ".gopclntab" or "__gopclntab". For binaries linked with an external
+// linker, the table is often not in its own section but embedded in another
+// section, for example ".data.rel.ro" or ".rdata". If the table is not located
+// in any of the file's sections, ErrSectionDoesNotExist is returned.
+func (f *GoFile) PCLNTabSection() (string, error) {
+	err := f.initPclntab()
+	if err != nil {
+		return "", err
+	}
+	return f.fh.getSectionNameFromAddress(f.pclntabAddr)
+}
+
+// Link modes returned by LinkMode.
+const (
+	// LinkModeInternal is the link mode for binaries linked by the Go linker.
+	LinkModeInternal = "internal"
+	// LinkModeExternal is the link mode for binaries linked by an external linker.
+	LinkModeExternal = "external"
+)
+
+// LinkMode returns LinkModeInternal if the binary was linked by the Go linker
+// and LinkModeExternal if it was linked by an external linker, for example
+// because it uses cgo. The location of the PCLN table can't be used to tell
+// them apart, since the external linker often keeps the table's section, so
+// the entry point is used instead. The Go linker uses the runtime's "_rt0_"
+// startup function as the entry point, while the entry point of an externally
+// linked binary is the C startup code, or the runtime's "main" function that
+// the C startup code calls.
+func (f *GoFile) LinkMode() (string, error) {
+	entry, err := f.fh.getEntryPoint()
+	if err != nil {
+		return "", err
+	}
+	tab, err := f.PCLNTab()
+	if err != nil {
+		return "", err
+	}
+	if fn := tab.PCToFunc(entry); fn != nil && fn.Entry == entry && strings.HasPrefix(fn.Name, "_rt0_") {
+		return LinkModeInternal, nil
+	}
+	return LinkModeExternal, nil
+}
+
+// TextRange returns the addresses of the "runtime.text" and "runtime.etext" symbols.
+// This is the range of the code generated by the Go toolchain, which all the function
+// addresses in the pclntab are relative to. For externally linked binaries, the range
+// can be a subset of the text section since the external linker can add additional
+// code to the section.
+func (f *GoFile) TextRange() (start, end uint64, err error) {
+	err = f.initPclntab()
+	if err != nil {
+		return 0, 0, err
+	}
+	if f.runtimeEtext != 0 {
+		return f.runtimeText, f.runtimeEtext, nil
+	}
+
+	// The end was not found when the start was resolved, get it from the moduledata.
+	err = f.initModuleData()
+	if err != nil {
+		return 0, 0, err
+	}
+	return f.runtimeText, f.moduledata.TextAddr + f.moduledata.TextLen, nil
+}
+
+// IsCodeAddress returns true if the address is inside executable code. This
+// is the case if the address is in the range returned by TextRange or in a
+// section of the file that holds executable code. It can be used to check if
+// a pointer targets code or data before disassembling from it.
+func (f *GoFile) IsCodeAddress(addr uint64) bool {
+	if start, end, err := f.TextRange(); err == nil && start <= addr && addr < end {
+		return true
+	}
+	return f.fh.isExecutableAddress(addr)
+}
+
+// Section describes a section of the file.
+type Section struct {
+	// Name is the name of the section, for example ".text".
+	Name string
+	// Address is the virtual address where the section starts.
+	Address uint64
+	// Size is the size of the section's data in the file.
+	Size uint64
+}
+
+// SectionForFunction returns the section that holds the code of the function.
+// Large binaries and binaries linked by an external linker may have the code
+// in more than one section. If the function's offset is not inside a section
+// holding executable code, ErrSectionDoesNotExist is returned.
+func (f *GoFile) SectionForFunction(fn *Function) (Section, error) {
+	if !f.fh.isExecutableAddress(fn.Offset) {
+		return Section{}, ErrSectionDoesNotExist
+	}
+	name, err := f.fh.getSectionNameFromAddress(fn.Offset)
+	if err != nil {
+		return Section{}, err
+	}
+	base, data, err := f.fh.getSectionDataFromAddress(fn.Offset)
+	if err != nil {
+		return Section{}, err
+	}
+	return Section{Name: name, Address: base, Size: uint64(len(data))}, nil
+}
+
+// CodeHash returns the hex encoded SHA-256 hash of the code generated by the
+// Go toolchain. The hash covers the range returned by TextRange, so the linker
+// padding and code added by an external linker are excluded. If the range
+// can't be resolved, the whole code section is hashed. Unlike the build ID,
+// the hash does not change if only the build information is changed, which
+// makes it useful for correlating samples.
+func (f *GoFile) CodeHash() (string, error) {
+	base, data, err := f.fh.getCodeSection()
+	if err != nil {
+		return "", err
+	}
+	if start, end, err := f.TextRange(); err == nil && start >= base && start <= end && end-base <= uint64(len(data)) {
+		data = data[start-base : end-base]
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// getPCLNTable returns a parser for the raw data stored in the PCLN table.
+func (f *GoFile) getPCLNTable() (*pclnTable, error) {
+	err := f.initPclntab()
+	if err != nil {
+		return nil, err
+	}
+	t, err := newPCLNTable(f.pclntabBytes, f.pclntabAddr, f.runtimeText, f.FileInfo.ByteOrder)
+	if err != nil {
+		return nil, err
+	}
+	if t.magic == gopclntab12magic {
+		// The encoding of the number of funcdata entries changed in Go 1.12
+		// without a change of the table's magic, so we need to know the
+		// compiler version to parse the function data correctly.
+		err = f.ensureCompilerVersion()
+		if err != nil {
+			return nil, err
+		}
+		t.legacyNFuncData = GoVersionCompare(f.FileInfo.goversion.Name, "go1.12beta1") < 0
+	}
+	return t, nil
+}
+
+func (f *GoFile) findRuntimeTextMachoChainedFixups(pclntabAddr uint64) (uint64, uint64, error) {
 	mf := f.fh.getParsedFile().(*macho.File)
 	fixups, err := mf.DyldChainedFixups()
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 	baseAddr := mf.GetBaseAddress()
 	var rebases []fixupchains.Rebase
@@ -505,17 +1333,60 @@ func (f *GoFile) findRuntimeTextMachoChainedFixups(pclntabAddr uint64) (uint64,
 			break
 		}
 	}
-	// then, find field 22
+	// then, find field 22 and 23
 	addr22 := moduledataAddr + 22*8
+	addr23 := moduledataAddr + 23*8
+	var text, etext uint64
 	for _, rb := range rebases {
-		if rb.Offset()+baseAddr == addr22 {
-			return baseAddr + rb.Target(), nil
+		switch rb.Offset() + baseAddr {
+		case addr22:
+			text = baseAddr + rb.Target()
+		case addr23:
+			etext = baseAddr + rb.Target()
+		}
+	}
+	if text == 0 {
+		return 0, 0, fmt.Errorf("failed to find runtime.text symbol")
+	}
+	return text, etext, nil
+}
+
+// findRuntimeTextPE searches the initialized data sections of a PE file, other
+// than the section returned by moduledataSection, for the moduledata structure
+// and returns the addresses of "runtime.text" and "runtime.etext" from it.
+func (f *GoFile) findRuntimeTextPE(textStart, textEnd, pclntabAddr uint64) (uint64, uint64, error) {
+	pf, ok := f.fh.getParsedFile().(*pe.File)
+	if !ok {
+		return 0, 0, fmt.Errorf("moduledata structure not found")
+	}
+	var sections [][]byte
+	for _, s := range pf.Sections {
+		if s.Name == f.fh.moduledataSection() || s.Characteristics&pe.IMAGE_SCN_CNT_INITIALIZED_DATA == 0 ||
+			s.Characteristics&(pe.IMAGE_SCN_MEM_EXECUTE|pe.IMAGE_SCN_CNT_CODE) != 0 {
+			continue
+		}
+		data, err := s.Data()
+		if err != nil {
+			continue
+		}
+		sections = append(sections, data)
+	}
+	return f.findRuntimeTextInSections(textStart, textEnd, pclntabAddr, sections)
+}
+
+// findRuntimeTextInSections is like findRuntimeText but searches each of the
+// sections in turn until the moduledata structure is found.
+func (f *GoFile) findRuntimeTextInSections(textStart, textEnd, pclntabAddr uint64, sections [][]byte) (uint64, uint64, error) {
+	for _, data := range sections {
+		text, etext, err := f.findRuntimeText(textStart, textEnd, pclntabAddr, data)
+		if err == nil {
+			return text, etext, nil
 		}
 	}
-	return 0, fmt.Errorf("failed to find runtime.text symbol")
+	return 0, 0, fmt.Errorf("moduledata structure not found")
 }
 
-func (f *GoFile) findRuntimeText(textStart, textEnd, pclntabAddr uint64, modSectiondata []byte) (uint64, error) {
+func (f *GoFile) findRuntimeText(textStart, textEnd, pclntabAddr uint64, modSectiondata []byte) (uint64, uint64, error) {
 	var text, etext uint64
 	magic := buildPclnTabAddrBinary(f.FileInfo.WordSize, f.FileInfo.ByteOrder, pclntabAddr)
 	for {
@@ -525,7 +1396,7 @@ func (f *GoFile) findRuntimeText(textStart, textEnd, pclntabAddr uint64, modSect
 		// If we got -1 back, nothing was found. If the offset is close to the end of the section
 		// it's not the correct match and we didn't find the structure.
 		if offset == -1 || len(modSectiondata[offset:]) < 30*f.FileInfo.WordSize {
-			return 0, fmt.Errorf("moduledata structure not found")
+			return 0, 0, fmt.Errorf("moduledata structure not found")
 		}
 
 		// We first check field 22 and 23 for runtime.text and runtime.etext. Current Go versions.
@@ -537,7 +1408,7 @@ func (f *GoFile) findRuntimeText(textStart, textEnd, pclntabAddr uint64, modSect
 			etext = f.FileInfo.ByteOrder.Uint64(modSectiondata[offset+23*f.FileInfo.WordSize:])
 		}
 		if text >= textStart && text < textEnd && etext > textStart && etext <= textEnd {
-			return text, nil
+			return text, etext, nil
 		}
 
 		// If fields 22 and 23 didn't return what we expected, we check fields 12 and 13. These fields
@@ -550,43 +1421,263 @@ func (f *GoFile) findRuntimeText(textStart, textEnd, pclntabAddr uint64, modSect
 			etext = f.FileInfo.ByteOrder.Uint64(modSectiondata[offset+13*f.FileInfo.WordSize:])
 		}
 		if text >= textStart && text < textEnd && etext > textStart && etext <= textEnd {
-			return text, nil
+			return text, etext, nil
 		}
 
 		modSectiondata = modSectiondata[offset+1:]
 	}
 }
 
-// GetTypes returns a map of all types found in the binary file.
+// GetTypes returns a map of all types found in the binary file. The types
+// include the unnamed types, such as anonymous structs, pointers, slices and
+// function signatures, that are referenced by other types. Use GetNamedTypes
+// to exclude them.
 func (f *GoFile) GetTypes() ([]*GoType, error) {
 	err := f.initModuleData()
 	if err != nil {
 		return nil, err
 	}
-	md := f.moduledata
 
-	t, err := getTypes(f.FileInfo, f.fh, md)
+	t, err := f.TypesFromModule(f.moduledata)
 	if err != nil {
 		return nil, err
 	}
 	if err = f.initPackages(); err != nil {
 		return nil, err
 	}
+	return t, nil
+}
+
+// GetNamedTypes returns the types like GetTypes but without the unnamed types.
+// A type is named if it is declared with a name, for example "main.myStruct",
+// or if it's a predeclared type such as "int" or "error".
+func (f *GoFile) GetNamedTypes() ([]*GoType, error) {
+	types, err := f.GetTypes()
+	if err != nil {
+		return nil, err
+	}
+	named := make([]*GoType, 0, len(types))
+	for _, t := range types {
+		if t.IsNamed() {
+			named = append(named, t)
+		}
+	}
+	return named, nil
+}
+
+// TypesFromModule returns the types in the typelinks of the given moduledata,
+// sorted like GetTypes. GetTypes uses the binary's first moduledata, this
+// makes it possible to choose the module. The moduledata must have been
+// returned by the library, for example by Moduledata, otherwise
+// ErrUnknownModuledata is returned.
+func (f *GoFile) TypesFromModule(md Moduledata) ([]*GoType, error) {
+	m, ok := md.(moduledata)
+	if !ok {
+		return nil, ErrUnknownModuledata
+	}
+	err := f.ensureCompilerVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := getTypes(f.FileInfo, f.fh, m)
+	if err != nil {
+		return nil, err
+	}
 	return sortTypes(t), nil
 }
 
+// TypesIter calls yield for each type in the binary. Unlike GetTypes, the
+// types are passed to yield as they are parsed instead of first collecting
+// all of them into a sorted slice. The types are not yielded in any particular
+// order. The iteration is stopped if yield returns false. Only a limited number
+// of parsed types are kept by the parser so types that are referenced by many
+// other types may be parsed more than once, but each type is only yielded once.
+func (f *GoFile) TypesIter(yield func(*GoType) bool) error {
+	err := f.initModuleData()
+	if err != nil {
+		return err
+	}
+	return iterTypes(f.FileInfo, f.fh, f.moduledata, typesIterCacheLimit, yield)
+}
+
+// ExportedTypeNames returns the names of the types listed in the binary's
+// typelinks, sorted and without duplicates. The typelinks hold the types the
+// runtime may need to look up, which approximates the set of types used by
+// the program. Only the names are resolved, so this is cheaper than GetTypes
+// if only the type inventory is needed. Named types are qualified with their
+// package path if it's known, for example "net/http.Client". Type literals,
+// such as "[]*http.Request", are returned as they are named by the runtime.
+// For binaries compiled with Go versions before 1.7, the names of all the
+// types are returned.
+func (f *GoFile) ExportedTypeNames() ([]string, error) {
+	err := f.initModuleData()
+	if err != nil {
+		return nil, err
+	}
+	return getTypeNames(f.FileInfo, f.fh, f.moduledata)
+}
+
+// ItabForAddress returns the interface type and the concrete type of the
+// runtime's itab structure at the address. The compiler loads the address of
+// an itab before a call through an interface, so it can be used to resolve the
+// dynamic type of the call. The itab starts with a pointer to the interface
+// type followed by a pointer to the concrete type. If the pointers don't point
+// to types in the types data, or the first is not an interface type,
+// ErrNotItab is returned. Types are only resolved for binaries compiled with
+// Go 1.7 or later, for older binaries ErrUnsupportedGoVersion is returned.
+func (f *GoFile) ItabForAddress(addr uint64) (iface *GoType, concrete *GoType, err error) {
+	err = f.initModuleData()
+	if err != nil {
+		return nil, nil, err
+	}
+	if GoVersionCompare(f.FileInfo.goversion.Name, "go1.7beta1") < 0 {
+		return nil, nil, fmt.Errorf("itabs are only resolved for Go 1.7 and later, not %s: %w", f.FileInfo.goversion.Name, ErrUnsupportedGoVersion)
+	}
+
+	ifaceAddr, err := f.ReadPointer(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	typeAddr, err := f.ReadPointer(addr + uint64(f.FileInfo.WordSize))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	types := f.moduledata.Types()
+	data, err := types.Data()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get types data section: %w", err)
+	}
+	inTypes := func(a uint64) bool {
+		return a >= types.Address && a-types.Address < uint64(len(data))
+	}
+	if !inTypes(ifaceAddr) || !inTypes(typeAddr) {
+		return nil, nil, ErrNotItab
+	}
+
+	p := newTypeParser(data, types.Address, f.FileInfo)
+	iface, err = p.parseType(ifaceAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the interface type at 0x%x: %w", ifaceAddr, err)
+	}
+	if iface.Kind != reflect.Interface {
+		return nil, nil, ErrNotItab
+	}
+	concrete, err = p.parseType(typeAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the concrete type at 0x%x: %w", typeAddr, err)
+	}
+	return iface, concrete, nil
+}
+
 // Bytes return a slice of raw bytes with the length in the file from the address.
+// If the data extends past the end of the section holding the address, it is read
+// from the following sections as long as they are adjacent in memory. This is the
+// case for example for strings that straddle the end of the ".rodata" section.
 func (f *GoFile) Bytes(address uint64, length uint64) ([]byte, error) {
 	base, section, err := f.fh.getSectionDataFromAddress(address)
 	if err != nil {
 		return nil, err
 	}
 
-	if address+length-base > uint64(len(section)) {
+	// Written so that a large length can't overflow the bounds check.
+	if address < base || address-base > uint64(len(section)) || address+length < address {
 		return nil, errors.New("length out of bounds")
 	}
+	if length <= uint64(len(section))-(address-base) {
+		return section[address-base : address+length-base], nil
+	}
+
+	data := append([]byte(nil), section[address-base:]...)
+	for uint64(len(data)) < length {
+		next := address + uint64(len(data))
+		base, section, err = f.fh.getSectionDataFromAddress(next)
+		// The next section has to start where the previous ended, otherwise
+		// there is a gap in the data.
+		if err != nil || base != next || len(section) == 0 {
+			return nil, errors.New("length out of bounds")
+		}
+		n := min(length-uint64(len(data)), uint64(len(section)))
+		data = append(data, section[:n]...)
+	}
+	return data, nil
+}
+
+// ReaderAt returns a reader over the data of the section that holds the address,
+// starting at the address. The reader ends at the end of the section, so it can
+// be used with for example binary.Read to parse a structure in the binary.
+func (f *GoFile) ReaderAt(addr uint64) (io.Reader, error) {
+	base, section, err := f.fh.getSectionDataFromAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	if addr < base || addr-base >= uint64(len(section)) {
+		return nil, errors.New("address out of bounds")
+	}
+	return bytes.NewReader(section[addr-base:]), nil
+}
 
-	return section[address-base : address+length-base], nil
+// ReadPointer reads a pointer sized value from the address. The size of the
+// value and the byte order is determined by the file's architecture.
+func (f *GoFile) ReadPointer(addr uint64) (uint64, error) {
+	data, err := f.Bytes(addr, uint64(f.FileInfo.WordSize))
+	if err != nil {
+		return 0, err
+	}
+	return readUIntTo64(bytes.NewReader(data), f.FileInfo.ByteOrder, f.FileInfo.WordSize == intSize32)
+}
+
+// ReadSliceHeader reads a slice header from the address and returns the address
+// of the slice's data, its length and its capacity.
+func (f *GoFile) ReadSliceHeader(addr uint64) (data, length, capacity uint64, err error) {
+	data, length, err = f.ReadStringHeader(addr)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	capacity, err = f.ReadPointer(addr + 2*uint64(f.FileInfo.WordSize))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return data, length, capacity, nil
+}
+
+// ReadStringHeader reads a string header from the address and returns the address
+// of the string's data and its length.
+func (f *GoFile) ReadStringHeader(addr uint64) (data, length uint64, err error) {
+	data, err = f.ReadPointer(addr)
+	if err != nil {
+		return 0, 0, err
+	}
+	length, err = f.ReadPointer(addr + uint64(f.FileInfo.WordSize))
+	if err != nil {
+		return 0, 0, err
+	}
+	return data, length, nil
+}
+
+// readStringSymbol reads the string variable located by the symbol. If the
+// binary doesn't have the symbol or the string is empty, an error is returned.
+func (f *GoFile) readStringSymbol(name string) (string, error) {
+	if !f.fh.hasSymbolTable() {
+		return "", ErrSymbolNotFound
+	}
+	sym, err := f.fh.getSymbol(name)
+	if err != nil {
+		return "", err
+	}
+	data, length, err := f.ReadStringHeader(sym.Value)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the string header of %s: %w", name, err)
+	}
+	if length == 0 {
+		return "", fmt.Errorf("%s is empty", name)
+	}
+	b, err := f.Bytes(data, length)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the string data of %s: %w", name, err)
+	}
+	return string(b), nil
 }
 
 func sortTypes(types map[uint64]*GoType) []*GoType {
@@ -599,6 +1690,11 @@ func sortTypes(types map[uint64]*GoType) []*GoType {
 	}
 	sort.Slice(sortedList, func(i, j int) bool {
 		if sortedList[i].PackagePath == sortedList[j].PackagePath {
+			// Unnamed types can share the same name, so the address is
+			// used to keep the order stable.
+			if sortedList[i].Name == sortedList[j].Name {
+				return sortedList[i].Addr < sortedList[j].Addr
+			}
 			return sortedList[i].Name < sortedList[j].Name
 		}
 		return sortedList[i].PackagePath < sortedList[j].PackagePath
@@ -610,12 +1706,17 @@ type fileHandler interface {
 	io.Closer
 	// returns the value, size and error
 	getSymbol(name string) (Symbol, error)
+	hasSymbolTable() bool
 	getRData() ([]byte, error)
 	getCodeSection() (uint64, []byte, error)
 	getSectionDataFromAddress(uint64) (uint64, []byte, error)
+	getSectionNameFromAddress(uint64) (string, error)
+	isExecutableAddress(uint64) bool
+	getEntryPoint() (uint64, error)
 	getSectionData(string) (uint64, []byte, error)
 	getFileInfo() *FileInfo
 	getPCLNTABData() (uint64, []byte, error)
+	getPCLNTABCandidates() []uint64
 	moduledataSection() string
 	getBuildID() (string, error)
 	getReader() io.ReaderAt
@@ -623,6 +1724,19 @@ type fileHandler interface {
 	getDwarf() (*dwarf.Data, error)
 }
 
+// byteOrderHandler is a fileHandler that reports the byte order set by the
+// user instead of the one from the file's header.
+type byteOrderHandler struct {
+	fileHandler
+	fileInfo *FileInfo
+}
+
+func (h *byteOrderHandler) getFileInfo() *FileInfo {
+	fi := h.fileHandler.getFileInfo()
+	fi.ByteOrder = h.fileInfo.ByteOrder
+	return fi
+}
+
 func fileMagicMatch(buf, magic []byte) bool {
 	return bytes.HasPrefix(buf, magic)
 }
@@ -638,6 +1752,9 @@ type FileInfo struct {
 	// WordSize is the natural integer size used by the file.
 	WordSize  int
 	goversion *GoVersion
+	// maxTypeDepth is the limit on how deep nested types are parsed. If
+	// zero, defaultMaxTypeDepth is used.
+	maxTypeDepth int
 }
 
 const (
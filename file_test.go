@@ -18,14 +18,22 @@
 package gore
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"debug/dwarf"
 	"debug/elf"
+	"debug/gosym"
 	"debug/pe"
+	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"io"
+	"math/rand"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"runtime/debug"
 	"strconv"
 	"strings"
 	"testing"
@@ -79,10 +87,11 @@ func TestIssue79PIEAndExternalLinker(t *testing.T) {
 		file     string
 		version  string
 		mainAddr uint64
+		linkMode string
 	}{
-		{"linux-pie-ext", "go1.21.3", uint64(0x000987a0)},
-		{"gold-darwin-arm64-1.21.0", "go1.21.0", uint64(0x100088370)},
-		{"windows-pie-ext", "go1.21.3", uint64(0x00485960)},
+		{"linux-pie-ext", "go1.21.3", uint64(0x000987a0), LinkModeExternal},
+		{"gold-darwin-arm64-1.21.0", "go1.21.0", uint64(0x100088370), LinkModeInternal},
+		{"windows-pie-ext", "go1.21.3", uint64(0x00485960), LinkModeExternal},
 	}
 
 	for _, test := range tests {
@@ -117,10 +126,100 @@ func TestIssue79PIEAndExternalLinker(t *testing.T) {
 			r.NotNil(mf, "Main function not found.")
 
 			r.Equal(test.mainAddr, mf.Offset, "Address of main.main not correct.")
+
+			lm, err := f.LinkMode()
+			r.NoError(err)
+			r.Equal(test.linkMode, lm)
+		})
+	}
+}
+
+func TestFindRuntimeTextInSections(t *testing.T) {
+	r := require.New(t)
+	const (
+		pclntabAddr = uint64(0x500000)
+		textStart   = uint64(0x401000)
+		textEnd     = uint64(0x480000)
+		// An external linker places C code before the Go code.
+		runtimeText  = uint64(0x401400)
+		runtimeEtext = uint64(0x47f000)
+	)
+	f := &GoFile{FileInfo: &FileInfo{WordSize: intSize64, ByteOrder: binary.LittleEndian}}
+
+	md := make([]byte, 32*intSize64)
+	binary.LittleEndian.PutUint64(md, pclntabAddr)
+	binary.LittleEndian.PutUint64(md[22*intSize64:], runtimeText)
+	binary.LittleEndian.PutUint64(md[23*intSize64:], runtimeEtext)
+	other := make([]byte, 0x40)
+
+	text, etext, err := f.findRuntimeTextInSections(textStart, textEnd, pclntabAddr, [][]byte{other, append(make([]byte, 8), md...)})
+	r.NoError(err)
+	r.Equal(runtimeText, text)
+	r.Equal(runtimeEtext, etext)
+
+	_, _, err = f.findRuntimeTextInSections(textStart, textEnd, pclntabAddr, [][]byte{other})
+	r.Error(err)
+}
+
+func TestRefineOS(t *testing.T) {
+	illumos := &BuildInfo{ModInfo: &debug.BuildInfo{Settings: []debug.BuildSetting{{Key: "GOOS", Value: "illumos"}}}}
+	solaris := &BuildInfo{ModInfo: &debug.BuildInfo{Settings: []debug.BuildSetting{{Key: "GOOS", Value: "solaris"}}}}
+	tests := []struct {
+		name     string
+		os       string
+		bi       *BuildInfo
+		expected string
+	}{
+		{"illumos", "solaris", illumos, "illumos"},
+		{"solaris", "solaris", solaris, "solaris"},
+		{"no settings", "solaris", &BuildInfo{}, "solaris"},
+		{"other os", "linux", illumos, "linux"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, refineOS(test.os, test.bi))
 		})
 	}
 }
 
+func TestPECgoExternalLinker(t *testing.T) {
+	// The binary needs a MinGW cross compiler, see testdata/build.go.
+	testFile := filepath.Join("testdata", "gold", "windows-cgo")
+	if _, err := os.Stat(testFile); err != nil {
+		t.Skip("No Windows cgo binary")
+	}
+	r := require.New(t)
+
+	f, err := Open(testFile)
+	r.NoError(err)
+	defer f.Close()
+	r.Equal("windows", f.FileInfo.OS)
+
+	tab, err := f.PCLNTab()
+	r.NoError(err)
+	mainFn := tab.LookupFunc("main.main")
+	r.NotNil(mainFn, "main.main should be in the pclntab")
+
+	md, err := f.Moduledata()
+	r.NoError(err)
+
+	// The C toolchain places its code before the Go code so runtime.text
+	// is not at the start of the text section.
+	textStart, textData, err := f.fh.getCodeSection()
+	r.NoError(err)
+	text := md.Text()
+	r.Greater(text.Address, textStart)
+	r.LessOrEqual(text.Address+text.Length, textStart+uint64(len(textData)))
+	r.Equal(f.runtimeText, text.Address)
+
+	// The function addresses are resolved relative to runtime.text.
+	r.GreaterOrEqual(mainFn.Entry, text.Address)
+	r.Less(mainFn.Entry, text.Address+text.Length)
+	fn, err := f.MainFunction()
+	r.NoError(err)
+	r.Equal(mainFn.Entry, fn.Offset)
+}
+
 func TestGoldFiles(t *testing.T) {
 	goldFiles, err := getGoldenResources()
 	if err != nil || len(goldFiles) == 0 {
@@ -177,7 +276,7 @@ func TestGoldFiles(t *testing.T) {
 
 			switch f.GetParsedFile().(type) {
 			case *elf.File:
-				require.Equal("linux", fileInfo[1], "Incorrect OS for "+file)
+				require.Equal(fileInfo[1], f.FileInfo.OS, "Incorrect OS for "+file)
 			case *macho.File:
 				require.Equal("darwin", fileInfo[1], "Incorrect OS for "+file)
 			case *pe.File:
@@ -215,19 +314,148 @@ func TestSetGoVersion(t *testing.T) {
 
 		assert.Nil(err, "Should not return an error when the version string is correct format")
 		assert.Equal(expected, f.FileInfo.goversion, "Incorrect go version has be set")
+
+		src, err := f.CompilerVersionSource()
+		assert.NoError(err)
+		assert.Equal(VersionSourceUser, src, "Incorrect version source")
+	})
+
+	t.Run("should clear the error from a failed extraction", func(t *testing.T) {
+		f := new(GoFile)
+		f.FileInfo = new(FileInfo)
+		// Simulate that the extraction has already failed.
+		f.versionOnce.Do(func() {
+			f.versionError = ErrNoGoVersionFound
+		})
+		_, err := f.GetCompilerVersion()
+		assert.ErrorIs(err, ErrNoGoVersionFound)
+
+		assert.NoError(f.SetGoVersion("go1.12"))
+
+		v, err := f.GetCompilerVersion()
+		assert.NoError(err)
+		assert.Equal(goversions["go1.12"], v)
+		src, err := f.CompilerVersionSource()
+		assert.NoError(err)
+		assert.Equal(VersionSourceUser, src)
 	})
+
+	t.Run("should discard the data extracted with the previous version", func(t *testing.T) {
+		r := require.New(t)
+		f := newTestGoFile(nil, &FileInfo{}, &Package{Name: "main"})
+		f.pclntabAddr = 0x1000
+		f.moduledata = moduledata{TextAddr: 0x1000}
+		f.initModuleDataError = errors.New("wrong layout")
+
+		r.NoError(f.SetGoVersion("go1.12"))
+		r.Equal(moduledata{}, f.moduledata)
+		r.NoError(f.initModuleDataError)
+		r.Nil(f.pkgs)
+		r.Equal(uint64(0x1000), f.pclntabAddr, "the located pclntab should be kept")
+
+		f.pclntabError = ErrNoPCLNTab
+		r.NoError(f.SetGoVersion("go1.12"))
+		r.NoError(f.pclntabError)
+		r.Zero(f.pclntabAddr)
+	})
+}
+
+func TestLocalPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		goroot   string
+		expected []string
+	}{
+		{"trimmed", []string{"fmt/print.go", "example.com/a/main.go"}, "", nil},
+		{
+			"goroot excluded",
+			[]string{"/usr/local/go/src/fmt/print.go", "/home/user/proj/main.go"},
+			"/usr/local/go",
+			[]string{"/home/user/proj"},
+		},
+		{
+			"sub folders collapsed",
+			[]string{"/home/user/proj/main.go", "/home/user/proj/pkg/a/a.go", "/home/user/proj-b/b.go"},
+			"",
+			[]string{"/home/user/proj", "/home/user/proj-b"},
+		},
+		{
+			"module cache",
+			[]string{"/home/user/go/pkg/mod/github.com/a/b@v1.0.0/b.go", "/home/user/proj/main.go"},
+			"",
+			[]string{"/home/user/go/pkg/mod", "/home/user/proj"},
+		},
+		{
+			"windows",
+			[]string{"C:/Users/user/proj/main.go", "C:/Program Files/Go/src/fmt/print.go"},
+			"C:/Program Files/Go",
+			[]string{"C:/Users/user/proj"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, localPaths(test.files, test.goroot))
+		})
+	}
+}
+
+func TestEnumPackagesNoMainPackage(t *testing.T) {
+	f := &GoFile{FileInfo: &FileInfo{ByteOrder: binary.LittleEndian}, pclntab: &gosym.Table{}}
+	assert.ErrorIs(t, f.enumPackages(), ErrNoMainPackage)
+}
+
+func TestSourceDir(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/home/user/proj/main.go", "/home/user/proj"},
+		{"/main.go", "/"},
+		{"main.go", "."},
+		{"C:/Users/user/proj/main.go", "C:/Users/user/proj"},
+		{"C:/main.go", "C:/"},
+		{`C:\Users\user\proj\main.go`, `C:\Users\user\proj`},
+		{`C:\main.go`, `C:\`},
+		{`\\server\share\proj\main.go`, `\\server\share\proj`},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, sourceDir(test.path))
+		})
+	}
 }
 
 type mockFileHandler struct {
 	mGetSectionDataFromAddress func(uint64) (uint64, []byte, error)
+	mGetSectionNameFromAddress func(uint64) (string, error)
+	mGetFileInfo               func() *FileInfo
+	mIsExecutableAddress       func(uint64) bool
+	mGetSymbol                 func(string) (Symbol, error)
+	mGetCodeSection            func() (uint64, []byte, error)
+	mGetSectionData            func(string) (uint64, []byte, error)
+	mModuledataSection         func() string
+	mGetDwarf                  func() (*dwarf.Data, error)
+	mGetReader                 func() io.ReaderAt
 }
 
 func (m *mockFileHandler) getReader() io.ReaderAt {
-	panic("not implemented")
+	if m.mGetReader == nil {
+		panic("not implemented")
+	}
+	return m.mGetReader()
 }
 
 func (m *mockFileHandler) getSymbol(name string) (Symbol, error) {
-	panic("not implemented")
+	if m.mGetSymbol == nil {
+		panic("not implemented")
+	}
+	return m.mGetSymbol(name)
+}
+
+func (m *mockFileHandler) hasSymbolTable() bool {
+	return m.mGetSymbol != nil
 }
 
 func (m *mockFileHandler) getParsedFile() any {
@@ -243,35 +471,85 @@ func (m *mockFileHandler) getRData() ([]byte, error) {
 }
 
 func (m *mockFileHandler) getCodeSection() (uint64, []byte, error) {
-	panic("not implemented")
+	if m.mGetCodeSection == nil {
+		panic("not implemented")
+	}
+	return m.mGetCodeSection()
 }
 
 func (m *mockFileHandler) getSectionDataFromAddress(a uint64) (uint64, []byte, error) {
 	return m.mGetSectionDataFromAddress(a)
 }
 
-func (m *mockFileHandler) getSectionData(string) (uint64, []byte, error) {
+func (m *mockFileHandler) getSectionNameFromAddress(a uint64) (string, error) {
+	if m.mGetSectionNameFromAddress == nil {
+		panic("not implemented")
+	}
+	return m.mGetSectionNameFromAddress(a)
+}
+
+func (m *mockFileHandler) getEntryPoint() (uint64, error) {
 	panic("not implemented")
 }
 
+func (m *mockFileHandler) isExecutableAddress(addr uint64) bool {
+	if m.mIsExecutableAddress == nil {
+		panic("not implemented")
+	}
+	return m.mIsExecutableAddress(addr)
+}
+
+func (m *mockFileHandler) getSectionData(name string) (uint64, []byte, error) {
+	if m.mGetSectionData == nil {
+		panic("not implemented")
+	}
+	return m.mGetSectionData(name)
+}
+
 func (m *mockFileHandler) getFileInfo() *FileInfo {
-	panic("not implemented")
+	if m.mGetFileInfo == nil {
+		panic("not implemented")
+	}
+	return m.mGetFileInfo()
 }
 
 func (m *mockFileHandler) getPCLNTABData() (uint64, []byte, error) {
 	panic("not implemented")
 }
 
-func (m *mockFileHandler) moduledataSection() string {
+func (m *mockFileHandler) getPCLNTABCandidates() []uint64 {
 	panic("not implemented")
 }
 
+func (m *mockFileHandler) moduledataSection() string {
+	if m.mModuledataSection == nil {
+		panic("not implemented")
+	}
+	return m.mModuledataSection()
+}
+
 func (m *mockFileHandler) getBuildID() (string, error) {
 	panic("not implemented")
 }
 
 func (m *mockFileHandler) getDwarf() (*dwarf.Data, error) {
-	panic("not implemented")
+	if m.mGetDwarf == nil {
+		panic("not implemented")
+	}
+	return m.mGetDwarf()
+}
+
+// newTestGoFile returns a GoFile for unit tests that uses the given file
+// handler and file info. The pclntab, the moduledata and the packages are
+// marked as already initialized so the data set up by the test is used
+// instead of being extracted from the file. The given packages are used as
+// the file's packages.
+func newTestGoFile(fh fileHandler, fi *FileInfo, pkgs ...*Package) *GoFile {
+	f := &GoFile{fh: fh, FileInfo: fi, pkgs: pkgs}
+	f.pclntabOnce.Do(func() {})
+	f.initModuleDataOnce.Do(func() {})
+	f.initPackagesOnce.Do(func() {})
+	return f
 }
 
 func TestBytes(t *testing.T) {
@@ -294,6 +572,412 @@ func TestBytes(t *testing.T) {
 	data, err := f.Bytes(address, length)
 	assert.NoError(err, "Should not return an error")
 	assert.Equal(expectedBytes, data, "Return data not as expected")
+
+	_, err = f.Bytes(address, length+7)
+	assert.Error(err, "Should return an error if the data is larger than the section")
+
+	// A length that overflows if added to the address must not panic.
+	assert.NotPanics(func() {
+		_, err = f.Bytes(address, ^uint64(0)-0x10)
+		assert.Error(err)
+	})
+}
+
+func TestBytesAcrossSections(t *testing.T) {
+	r := require.New(t)
+	sections := map[uint64][]byte{
+		0x1000: {0x0, 0x1, 0x2, 0x3},
+		0x1004: {0x4, 0x5},
+		0x1006: {0x6, 0x7, 0x8},
+		0x2000: {0x9, 0xa},
+	}
+	fh := &mockFileHandler{
+		mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+			for base, data := range sections {
+				if base <= a && a < base+uint64(len(data)) {
+					return base, data, nil
+				}
+			}
+			return 0, nil, errors.New("out of bound")
+		},
+	}
+	f := &GoFile{fh: fh}
+
+	data, err := f.Bytes(0x1002, 6)
+	r.NoError(err)
+	r.Equal([]byte{0x2, 0x3, 0x4, 0x5, 0x6, 0x7}, data)
+
+	_, err = f.Bytes(0x1006, 4)
+	r.Error(err, "should not read across a gap between sections")
+}
+
+func TestReaderAt(t *testing.T) {
+	r := require.New(t)
+	base := uint64(0x40000)
+	section := []byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8}
+	fh := &mockFileHandler{
+		mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+			if a >= base+uint64(len(section)) || a < base {
+				return 0, nil, errors.New("out of bound")
+			}
+			return base, section, nil
+		},
+	}
+	f := &GoFile{fh: fh}
+
+	rd, err := f.ReaderAt(base + 2)
+	r.NoError(err)
+	var v uint32
+	r.NoError(binary.Read(rd, binary.LittleEndian, &v))
+	r.Equal(uint32(0x06050403), v)
+
+	// The reader ends at the end of the section.
+	r.ErrorIs(binary.Read(rd, binary.LittleEndian, &v), io.ErrUnexpectedEOF)
+
+	_, err = f.ReaderAt(base + uint64(len(section)))
+	r.Error(err)
+}
+
+func TestReadPointer(t *testing.T) {
+	base := uint64(0x40000)
+	section := []byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9}
+	fh := &mockFileHandler{
+		mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+			if a >= base+uint64(len(section)) || a < base {
+				return 0, nil, errors.New("out of bound")
+			}
+			return base, section, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		info     *FileInfo
+		addr     uint64
+		expected uint64
+	}{
+		{"64-bit little endian", &FileInfo{WordSize: intSize64, ByteOrder: binary.LittleEndian}, base, 0x0807060504030201},
+		{"64-bit big endian", &FileInfo{WordSize: intSize64, ByteOrder: binary.BigEndian}, base + 1, 0x0203040506070809},
+		{"32-bit little endian", &FileInfo{WordSize: intSize32, ByteOrder: binary.LittleEndian}, base + 4, 0x08070605},
+		{"32-bit big endian", &FileInfo{WordSize: intSize32, ByteOrder: binary.BigEndian}, base, 0x01020304},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := &GoFile{fh: fh, FileInfo: test.info}
+			v, err := f.ReadPointer(test.addr)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, v)
+		})
+	}
+
+	t.Run("out of bounds", func(t *testing.T) {
+		f := &GoFile{fh: fh, FileInfo: &FileInfo{WordSize: intSize64, ByteOrder: binary.LittleEndian}}
+		_, err := f.ReadPointer(base + 4)
+		assert.Error(t, err)
+	})
+}
+
+func TestReadSliceHeader(t *testing.T) {
+	base := uint64(0x40000)
+	section := []byte{
+		0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+	fh := &mockFileHandler{
+		mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+			if a >= base+uint64(len(section)) || a < base {
+				return 0, nil, errors.New("out of bound")
+			}
+			return base, section, nil
+		},
+	}
+
+	t.Run("64-bit", func(t *testing.T) {
+		r := require.New(t)
+		f := &GoFile{fh: fh, FileInfo: &FileInfo{WordSize: intSize64, ByteOrder: binary.LittleEndian}}
+
+		data, length, capacity, err := f.ReadSliceHeader(base)
+		r.NoError(err)
+		r.Equal(uint64(0x1000), data)
+		r.Equal(uint64(3), length)
+		r.Equal(uint64(5), capacity)
+
+		data, length, err = f.ReadStringHeader(base)
+		r.NoError(err)
+		r.Equal(uint64(0x1000), data)
+		r.Equal(uint64(3), length)
+
+		_, _, _, err = f.ReadSliceHeader(base + 8)
+		r.Error(err)
+	})
+
+	t.Run("32-bit", func(t *testing.T) {
+		r := require.New(t)
+		f := &GoFile{fh: fh, FileInfo: &FileInfo{WordSize: intSize32, ByteOrder: binary.LittleEndian}}
+
+		data, length, capacity, err := f.ReadSliceHeader(base + 8)
+		r.NoError(err)
+		r.Equal(uint64(3), data)
+		r.Equal(uint64(0), length)
+		r.Equal(uint64(5), capacity)
+	})
+}
+
+// countingCloser counts the number of times the reader is closed.
+type countingCloser struct {
+	io.ReaderAt
+	closed int
+}
+
+func (c *countingCloser) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestDoubleClose(t *testing.T) {
+	r := require.New(t)
+
+	reader := &countingCloser{ReaderAt: bytes.NewReader(buildTestCore(0, 0, nil, nil))}
+	fh, err := openELF(reader)
+	r.NoError(err)
+	f := &GoFile{fh: fh}
+
+	r.NoError(f.Close())
+	r.NoError(f.Close())
+	r.NoError(fh.Close())
+	r.Equal(1, reader.closed)
+}
+
+func TestSetByteOrder(t *testing.T) {
+	a := assert.New(t)
+	fh := &mockFileHandler{
+		mGetSectionDataFromAddress: func(uint64) (uint64, []byte, error) {
+			return 0x1000, []byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8}, nil
+		},
+		mGetFileInfo: func() *FileInfo {
+			return &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64}
+		},
+	}
+	f := newTestGoFile(fh, fh.getFileInfo(), &Package{Name: "main"})
+	f.pclntabBytes = []byte{0x1}
+
+	f.SetByteOrder(binary.BigEndian)
+	f.SetByteOrder(binary.BigEndian)
+
+	a.Equal(binary.BigEndian, f.FileInfo.ByteOrder)
+	a.Equal(binary.BigEndian, f.fh.getFileInfo().ByteOrder)
+	a.Equal(fh, f.fh.(*byteOrderHandler).fileHandler, "the handler should only be wrapped once")
+
+	ptr, err := f.ReadPointer(0x1000)
+	a.NoError(err)
+	a.Equal(uint64(0x0102030405060708), ptr)
+
+	a.Nil(f.pclntabBytes)
+	a.Nil(f.pkgs)
+	ran := false
+	f.initPackagesOnce.Do(func() { ran = true })
+	a.True(ran, "the packages should be initialized again")
+}
+
+func TestResetCachesVersion(t *testing.T) {
+	tests := []struct {
+		source string
+		kept   bool
+	}{
+		{VersionSourceUser, true},
+		{VersionSourceBuildInfo, true},
+		{VersionSourceDwarf, false},
+		{VersionSourceSchedInit, false},
+		{VersionSourceScan, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			a := assert.New(t)
+			f := newTestGoFile(nil, &FileInfo{goversion: ResolveGoVersion("go1.22.0")})
+			f.versionOnce.Do(func() {})
+			f.versionSource = test.source
+
+			f.resetCaches()
+
+			ran := false
+			f.versionOnce.Do(func() { ran = true })
+			if test.kept {
+				a.False(ran)
+				a.Equal("go1.22.0", f.FileInfo.goversion.Name)
+				a.Equal(test.source, f.versionSource)
+			} else {
+				a.True(ran, "the version should be extracted again")
+				a.Nil(f.FileInfo.goversion)
+				a.Empty(f.versionSource)
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		f := newTestGoFile(nil, &FileInfo{})
+		f.versionOnce.Do(func() {})
+		f.versionError = ErrNoGoVersionFound
+
+		f.resetCaches()
+
+		assert.NoError(t, f.versionError)
+	})
+}
+
+func TestIsCodeAddress(t *testing.T) {
+	a := assert.New(t)
+	f := newTestGoFile(&mockFileHandler{
+		mIsExecutableAddress: func(addr uint64) bool {
+			return addr >= 0x3000 && addr < 0x4000
+		},
+	}, nil)
+	f.runtimeText = 0x1000
+	f.runtimeEtext = 0x2000
+
+	a.True(f.IsCodeAddress(0x1000))
+	a.True(f.IsCodeAddress(0x1fff))
+	a.False(f.IsCodeAddress(0x2000))
+	a.True(f.IsCodeAddress(0x3000), "address in an executable section")
+	a.False(f.IsCodeAddress(0x4000))
+	a.False(f.IsCodeAddress(0x500))
+}
+
+func TestSectionForFunction(t *testing.T) {
+	r := require.New(t)
+	text := make([]byte, 0x1000)
+	f := newTestGoFile(&mockFileHandler{
+		mIsExecutableAddress: func(addr uint64) bool {
+			return addr >= 0x3000 && addr < 0x4000
+		},
+		mGetSectionNameFromAddress: func(uint64) (string, error) {
+			return ".text", nil
+		},
+		mGetSectionDataFromAddress: func(uint64) (uint64, []byte, error) {
+			return 0x3000, text, nil
+		},
+	}, nil)
+
+	sect, err := f.SectionForFunction(&Function{Offset: 0x3100, End: 0x3200})
+	r.NoError(err)
+	r.Equal(Section{Name: ".text", Address: 0x3000, Size: 0x1000}, sect)
+
+	_, err = f.SectionForFunction(&Function{Offset: 0x5000, End: 0x5100})
+	r.ErrorIs(err, ErrSectionDoesNotExist)
+}
+
+func TestCodeHash(t *testing.T) {
+	code := []byte{0xcc, 0x01, 0x02, 0x03, 0xcc, 0xcc}
+	newFile := func() *GoFile {
+		return newTestGoFile(&mockFileHandler{
+			mGetCodeSection: func() (uint64, []byte, error) {
+				return 0x1000, code, nil
+			},
+		}, nil)
+	}
+	hash := func(data []byte) string {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+
+	t.Run("text range", func(t *testing.T) {
+		f := newFile()
+		f.runtimeText = 0x1001
+		f.runtimeEtext = 0x1004
+		h, err := f.CodeHash()
+		require.NoError(t, err)
+		assert.Equal(t, hash(code[1:4]), h)
+	})
+
+	t.Run("range outside of section", func(t *testing.T) {
+		f := newFile()
+		f.runtimeText = 0x1001
+		f.runtimeEtext = 0x2000
+		h, err := f.CodeHash()
+		require.NoError(t, err)
+		assert.Equal(t, hash(code), h)
+	})
+
+	t.Run("no code section", func(t *testing.T) {
+		f := newTestGoFile(&mockFileHandler{
+			mGetCodeSection: func() (uint64, []byte, error) {
+				return 0, nil, ErrSectionDoesNotExist
+			},
+		}, nil)
+		_, err := f.CodeHash()
+		assert.ErrorIs(t, err, ErrSectionDoesNotExist)
+	})
+}
+
+func TestIsPacked(t *testing.T) {
+	code := bytes.Repeat([]byte{0x48, 0x89, 0xe5, 0xc3}, 0x400)
+	compressed := make([]byte, 0x1000)
+	rand.New(rand.NewSource(1)).Read(compressed)
+
+	tests := []struct {
+		name     string
+		sections []testELFNote
+		packed   bool
+	}{
+		{"code", []testELFNote{{".text", code}}, false},
+		{"compressed code", []testELFNote{{".text", compressed}}, true},
+		{"no code section", nil, false},
+		{"upx magic", []testELFNote{{".note", []byte("\x00UPX!\x00")}, {".text", code}}, true},
+		{"upx section", []testELFNote{{".text", code}, {"UPX0", nil}}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			f, err := OpenReader(bytes.NewReader(buildTestELF(elf.EM_X86_64, elf.ELFOSABI_NONE, test.sections...)))
+			r.NoError(err)
+			r.Equal(test.packed, f.IsPacked())
+
+			_, err = f.PCLNTab()
+			r.Error(err)
+			r.Equal(test.packed, errors.Is(err, ErrLikelyPacked))
+		})
+	}
+}
+
+func TestIsTinyGo(t *testing.T) {
+	tests := []struct {
+		name   string
+		symbol string
+		tinygo bool
+	}{
+		{"scan stack", "tinygo_scanCurrentStack", true},
+		{"init all", "runtime.initAll", true},
+		{"gc", "runtime.doInit", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			fh := &mockFileHandler{
+				mGetSymbol: func(name string) (Symbol, error) {
+					if name == test.symbol {
+						return Symbol{Name: name}, nil
+					}
+					return Symbol{}, ErrSymbolNotFound
+				},
+			}
+			f := &GoFile{fh: fh}
+			// Skip the gccgo and packer checks, they need a parsed file.
+			f.gccgoOnce.Do(func() {})
+			f.packedOnce.Do(func() {})
+			r.Equal(test.tinygo, f.IsTinyGo())
+
+			err := f.compilerError(ErrNoPCLNTab)
+			r.Equal(test.tinygo, errors.Is(err, ErrUnsupportedCompiler))
+		})
+	}
+}
+
+func TestTypesFromUnknownModule(t *testing.T) {
+	f := &GoFile{}
+	_, err := f.TypesFromModule(struct{ Moduledata }{})
+	assert.ErrorIs(t, err, ErrUnknownModuledata)
 }
 
 func getTestResourcePath(resource string) (string, error) {
@@ -343,3 +1027,195 @@ func main() {
 	fmt.Println(data)
 }
 `
+
+func TestRelativeSourcePath(t *testing.T) {
+	fh := &mockFileHandler{
+		mGetDwarf: func() (*dwarf.Data, error) {
+			return nil, errors.New("no DWARF data")
+		},
+	}
+	newFile := func(goroot string) *GoFile {
+		f := newTestGoFile(fh, &FileInfo{Arch: ArchMIPS, WordSize: intSize32})
+		if goroot != "" {
+			f.stdPkgs = []*Package{{Name: "runtime", Filepath: goroot + "/src/runtime"}}
+		}
+		f.BuildInfo = &BuildInfo{ModInfo: &debug.BuildInfo{
+			Path: "github.com/foo/bar/cmd/bar",
+			Main: debug.Module{Path: "github.com/foo/bar"},
+		}}
+		return f
+	}
+
+	tests := []struct {
+		name     string
+		goroot   string
+		fn       *Function
+		expected string
+	}{
+		{
+			"goroot",
+			"/usr/local/go",
+			&Function{Name: "main", PackageName: "runtime", Filename: "/usr/local/go/src/runtime/proc.go"},
+			"src/runtime/proc.go",
+		},
+		{
+			"windows goroot",
+			`C:\Program Files\Go`,
+			&Function{Name: "main", PackageName: "runtime", Filename: `C:\Program Files\Go\src\runtime\proc.go`},
+			`src\runtime\proc.go`,
+		},
+		{
+			"no goroot",
+			"",
+			&Function{Name: "main", PackageName: "runtime", Filename: "/usr/local/go/src/runtime/proc.go"},
+			"/usr/local/go/src/runtime/proc.go",
+		},
+		{
+			"module cache",
+			"/usr/local/go",
+			&Function{Name: "Baz", PackageName: "github.com/baz/baz", Filename: "/home/user/go/pkg/mod/github.com/baz/baz@v1.2.3/baz.go"},
+			"github.com/baz/baz@v1.2.3/baz.go",
+		},
+		{
+			"main package",
+			"/usr/local/go",
+			&Function{Name: "main", PackageName: "main", Filename: "/home/user/src/bar/cmd/bar/main.go"},
+			"github.com/foo/bar/cmd/bar/main.go",
+		},
+		{
+			"main module package",
+			"/usr/local/go",
+			&Function{Name: "Foo", PackageName: "github.com/foo/bar/internal/foo", Filename: "/home/user/src/bar/internal/foo/foo.go"},
+			"github.com/foo/bar/internal/foo/foo.go",
+		},
+		{
+			"windows main module package",
+			"/usr/local/go",
+			&Function{Name: "Foo", PackageName: "github.com/foo/bar/internal/foo", Filename: `C:\src\bar\internal\foo\foo.go`},
+			"github.com/foo/bar/internal/foo/foo.go",
+		},
+		{
+			"trimpath",
+			"",
+			&Function{Name: "Foo", PackageName: "github.com/foo/bar/internal/foo", Filename: "github.com/foo/bar/internal/foo/foo.go"},
+			"github.com/foo/bar/internal/foo/foo.go",
+		},
+		{
+			"autogenerated",
+			"/usr/local/go",
+			&Function{Name: "Foo", PackageName: "main", Filename: "<autogenerated>"},
+			"<autogenerated>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := newFile(test.goroot).RelativeSourcePath(test.fn)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, p)
+		})
+	}
+}
+
+func TestSourceInfoFastLoadsPCLNTab(t *testing.T) {
+	r := require.New(t)
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("No go tool chain found")
+	}
+	tmpdir := t.TempDir()
+	src := filepath.Join(tmpdir, "a.go")
+	r.NoError(os.WriteFile(src, []byte(testresourcesrc), 0644))
+	exe := filepath.Join(tmpdir, "a")
+	cmd := exec.Command(goBin, "build", "-o", exe, src)
+	cmd.Env = append(os.Environ(), "GOOS=linux", "GOARCH=amd64", "CGO_ENABLED=0")
+	out, err := cmd.CombinedOutput()
+	r.NoError(err, string(out))
+
+	f, err := Open(exe)
+	r.NoError(err)
+	defer f.Close()
+
+	sym, err := f.fh.getSymbol("main.getData")
+	r.NoError(err)
+
+	// Nothing has loaded the PCLN table yet.
+	file, line, err := f.SourceInfoFast(&Function{Offset: sym.Value})
+	r.NoError(err)
+	assert.Equal(t, "a.go", filepath.Base(file))
+	assert.NotZero(t, line)
+}
+
+func TestSourceInfoFastNoPCLNTab(t *testing.T) {
+	f := newTestGoFile(&mockFileHandler{}, &FileInfo{Arch: ArchAMD64, WordSize: intSize64})
+	f.initPackagesError = ErrNoPCLNTab
+	_, _, err := f.SourceInfoFast(&Function{Offset: 0x1000})
+	assert.ErrorIs(t, err, ErrNoPCLNTab)
+}
+
+func TestItabForAddress(t *testing.T) {
+	const (
+		typesAddr = 0x1000
+		ifaceOff  = 0x10
+		itabAddr  = 0x2000
+	)
+	le := binary.LittleEndian
+	rtype := func(kind reflect.Kind) []byte {
+		buf := &bytes.Buffer{}
+		require.NoError(t, binary.Write(buf, le, rtypeGo64{Kind: uint8(kind)}))
+		return buf.Bytes()
+	}
+	// The name "T" is followed by an interface type without methods and a
+	// pointer type that points to the interface type.
+	types := make([]byte, ifaceOff)
+	copy(types, "\x00\x01T")
+	types = append(types, rtype(reflect.Interface)...)
+	types = append(types, make([]byte, 4*intSize64)...)
+	ptrOff := uint64(len(types))
+	types = append(types, rtype(reflect.Ptr)...)
+	types = le.AppendUint64(types, typesAddr+ifaceOff)
+
+	itab := func(inter, typ uint64) []byte {
+		return le.AppendUint64(le.AppendUint64(nil, inter), typ)
+	}
+	tests := []struct {
+		name      string
+		goversion string
+		itab      []byte
+		err       error
+	}{
+		{"valid", "go1.22", itab(typesAddr+ifaceOff, typesAddr+ptrOff), nil},
+		{"not interface", "go1.22", itab(typesAddr+ptrOff, typesAddr+ifaceOff), ErrNotItab},
+		{"outside types", "go1.22", itab(typesAddr+ifaceOff, itabAddr), ErrNotItab},
+		{"old version", "go1.6", itab(typesAddr+ifaceOff, typesAddr+ptrOff), ErrUnsupportedGoVersion},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			fh := &mockFileHandler{
+				mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+					if a >= itabAddr && a < itabAddr+uint64(len(test.itab)) {
+						return itabAddr, test.itab, nil
+					}
+					if a >= typesAddr && a < typesAddr+uint64(len(types)) {
+						return typesAddr, types, nil
+					}
+					return 0, nil, ErrSectionDoesNotExist
+				},
+			}
+			fi := &FileInfo{ByteOrder: le, WordSize: intSize64, goversion: &GoVersion{Name: test.goversion}}
+			f := newTestGoFile(fh, fi)
+			f.moduledata = moduledata{TypesAddr: typesAddr, TypesLen: uint64(len(types)), fh: fh}
+
+			iface, concrete, err := f.ItabForAddress(itabAddr)
+			if test.err != nil {
+				r.ErrorIs(err, test.err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(reflect.Interface, iface.Kind)
+			r.Equal(reflect.Ptr, concrete.Kind)
+			r.Same(iface, concrete.Element)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"sort"
+	"strings"
+)
+
+// knownFrameworks maps the name of a well-known framework or library to the
+// import paths of its packages. A package matches if its import path is one of
+// the paths or is a package below one of them.
+var knownFrameworks = []struct {
+	name  string
+	paths []string
+}{
+	// Web frameworks.
+	{"Gin", []string{"github.com/gin-gonic/gin"}},
+	{"Echo", []string{"github.com/labstack/echo"}},
+	{"Fiber", []string{"github.com/gofiber/fiber"}},
+	{"Gorilla", []string{"github.com/gorilla/mux", "github.com/gorilla/websocket"}},
+	{"Chi", []string{"github.com/go-chi/chi"}},
+	{"fasthttp", []string{"github.com/valyala/fasthttp"}},
+
+	// Command line applications.
+	{"Cobra", []string{"github.com/spf13/cobra"}},
+	{"Viper", []string{"github.com/spf13/viper"}},
+	{"urfave/cli", []string{"github.com/urfave/cli"}},
+
+	// RPC and cloud.
+	{"gRPC", []string{"google.golang.org/grpc"}},
+	{"Protocol Buffers", []string{"google.golang.org/protobuf", "github.com/golang/protobuf"}},
+	{"Kubernetes", []string{"k8s.io/client-go", "k8s.io/apimachinery", "k8s.io/api", "k8s.io/kubernetes"}},
+	{"Docker", []string{"github.com/docker/docker", "github.com/docker/cli"}},
+	{"AWS SDK", []string{"github.com/aws/aws-sdk-go", "github.com/aws/aws-sdk-go-v2"}},
+	{"Google Cloud", []string{"cloud.google.com/go"}},
+	{"Azure SDK", []string{"github.com/Azure/azure-sdk-for-go"}},
+
+	// Command and control frameworks and tunneling tools.
+	{"Sliver", []string{"github.com/bishopfox/sliver"}},
+	{"Merlin", []string{"github.com/Ne0nd0g/merlin", "github.com/Ne0nd0g/merlin-agent"}},
+	{"Poseidon", []string{"github.com/MythicAgents/poseidon"}},
+	{"Chisel", []string{"github.com/jpillora/chisel"}},
+	{"Ligolo-ng", []string{"github.com/nicocha30/ligolo-ng"}},
+}
+
+// DetectFrameworks returns the names of the well-known frameworks and libraries,
+// for example "Gin", "Cobra" or "gRPC", that the binary is built with. The
+// names are matched against the import paths of the vendor packages. The main
+// packages are also checked, since a binary built from the framework's own
+// repository has its packages in the main module. The names are sorted. If
+// the packages can't be enumerated, nil is returned.
+func (f *GoFile) DetectFrameworks() []string {
+	pkgs, err := f.GetPackages()
+	if err != nil {
+		return nil
+	}
+	vendors, err := f.GetVendors()
+	if err != nil {
+		return nil
+	}
+
+	var found []string
+	for _, fw := range knownFrameworks {
+		if hasPackageBelow(vendors, fw.paths) || hasPackageBelow(pkgs, fw.paths) {
+			found = append(found, fw.name)
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+// hasPackageBelow returns true if any of the packages is one of the import
+// paths or a package below one of them.
+func hasPackageBelow(pkgs []*Package, paths []string) bool {
+	for _, p := range pkgs {
+		for _, path := range paths {
+			if p.Name == path || strings.HasPrefix(p.Name, path+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
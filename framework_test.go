@@ -0,0 +1,44 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectFrameworks(t *testing.T) {
+	r := require.New(t)
+
+	f := newTestGoFile(nil, nil,
+		&Package{Name: "main"},
+		&Package{Name: "github.com/bishopfox/sliver/implant/sliver/transports"},
+	)
+	f.vendors = []*Package{
+		{Name: "github.com/gin-gonic/gin/render"},
+		{Name: "google.golang.org/grpc"},
+		{Name: "github.com/aws/aws-sdk-go-v2/service/s3"},
+		// Only a prefix of the path element, not the framework.
+		{Name: "github.com/spf13/cobra-cli"},
+	}
+
+	r.Equal([]string{"AWS SDK", "Gin", "Sliver", "gRPC"}, f.DetectFrameworks())
+
+	r.Empty(newTestGoFile(nil, nil, &Package{Name: "main"}).DetectFrameworks())
+}
@@ -0,0 +1,168 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"fmt"
+	"math"
+)
+
+// Indexes of the funcdata tables used by the runtime. The indexes are
+// defined in the runtime's "funcdata.h" file. Not all tables exist in all
+// Go versions.
+const (
+	FuncDataArgsPointerMaps    = 0
+	FuncDataLocalsPointerMaps  = 1
+	FuncDataStackObjects       = 2
+	FuncDataInlTree            = 3
+	FuncDataOpenCodedDeferInfo = 4
+	FuncDataArgInfo            = 5
+	FuncDataArgLiveInfo        = 6
+	FuncDataWrapInfo           = 7
+)
+
+// Indexes of the pcdata tables used by the runtime. The indexes are defined
+// in the runtime's "funcdata.h" file. The meaning of index 0 has changed
+// between Go versions; older versions used it for the argument size and
+// later for the register map.
+const (
+	PCDataUnsafePoint   = 0
+	PCDataStackMapIndex = 1
+	PCDataInlTreeIndex  = 2
+	PCDataArgLiveIndex  = 3
+)
+
+// FuncData returns the data referenced by the function's funcdata table of the
+// given kind, for example FuncDataInlTree for the inline tree. The size of the
+// data is not stored in the binary, so the returned slice starts at the
+// referenced data and extends to the end of the section it is located in. It is
+// up to the caller to parse the data based on the kind. If the function doesn't
+// have the table, ErrNoFuncData is returned.
+func (f *GoFile) FuncData(fn *Function, kind int) ([]byte, error) {
+	fi, err := f.funcInfo(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	val, ok := fi.funcdataValue(kind)
+	if !ok {
+		return nil, ErrNoFuncData
+	}
+
+	addr := val
+	if fi.t.magic == gopclntab118magic || fi.t.magic == gopclntab120magic {
+		// Since Go 1.18, the value is an offset from the "go:func.*" symbol.
+		if val == math.MaxUint32 {
+			return nil, ErrNoFuncData
+		}
+		md, err := f.Moduledata()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the moduledata needed to resolve the funcdata: %w", err)
+		}
+		addr = md.GoFuncValue() + val
+	} else if val == 0 {
+		return nil, ErrNoFuncData
+	}
+
+	base, data, err := f.fh.getSectionDataFromAddress(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the funcdata at 0x%x: %w", addr, err)
+	}
+	return data[addr-base:], nil
+}
+
+// PCData returns the encoded pc-value table for the function's pcdata table
+// with the given index, for example PCDataInlTreeIndex. The table is a
+// sequence of varint encoded value and pc delta pairs, as used by the runtime.
+// If the function doesn't have the table, ErrNoPCData is returned.
+func (f *GoFile) PCData(fn *Function, table int) ([]byte, error) {
+	fi, err := f.funcInfo(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	off, ok := fi.pcdataOffset(table)
+	if !ok || off == 0 {
+		return nil, ErrNoPCData
+	}
+	if uint64(off) >= uint64(len(fi.t.pctab)) {
+		return nil, fmt.Errorf("pcdata table offset 0x%x is out of bounds", off)
+	}
+
+	p := fi.t.pctab[off:]
+	return p[:pcvalueTableSize(p)], nil
+}
+
+// PCValue is a range of program counters that share the same value in a
+// pc-value table.
+type PCValue struct {
+	// Start is the first pc of the range.
+	Start uint64
+	// End is the pc following the range.
+	End uint64
+	// Value is the value for the pcs in the range.
+	Value int32
+}
+
+// PCTables holds the decoded pc-value tables of a function that the runtime
+// uses for stack unwinding and to map the pcs to the source code.
+type PCTables struct {
+	// SP holds the stack pointer delta from the function's entry.
+	SP []PCValue
+	// File holds the file number of the source file. Since Go 1.16, the
+	// number is an index into the files of the function's compilation unit,
+	// before it is an index into the table of all files.
+	File []PCValue
+	// Line holds the source line number.
+	Line []PCValue
+}
+
+// PCTables returns the decoded pcsp, pcfile and pcln tables of the function.
+// The ranges cover the code of the function in order. A table that the
+// function doesn't have is left empty.
+func (f *GoFile) PCTables(fn *Function) (*PCTables, error) {
+	fi, err := f.funcInfo(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	tabs := &PCTables{}
+	if tabs.SP, err = fi.t.pcvalueTable(fi.pcsp, fi.entry); err != nil {
+		return nil, fmt.Errorf("failed to decode the pcsp table: %w", err)
+	}
+	if tabs.File, err = fi.t.pcvalueTable(fi.pcfile, fi.entry); err != nil {
+		return nil, fmt.Errorf("failed to decode the pcfile table: %w", err)
+	}
+	if tabs.Line, err = fi.t.pcvalueTable(fi.pcln, fi.entry); err != nil {
+		return nil, fmt.Errorf("failed to decode the pcln table: %w", err)
+	}
+	return tabs, nil
+}
+
+// funcInfo returns the runtime metadata stored in the PCLN table for the function.
+func (f *GoFile) funcInfo(fn *Function) (*funcInfo, error) {
+	t, err := f.getPCLNTable()
+	if err != nil {
+		return nil, err
+	}
+	i, ok := t.findFunc(fn.Offset)
+	if !ok {
+		return nil, ErrFunctionNotFound
+	}
+	return t.funcInfo(i)
+}
@@ -0,0 +1,107 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPCValueTableSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		table    []byte
+		expected int
+	}{
+		{"empty", []byte{}, 0},
+		{"zero first value", []byte{0x00, 0x04, 0x00, 0xff}, 3},
+		{"single entry", []byte{0x02, 0x10, 0x00}, 3},
+		{"varint deltas", []byte{0x93, 0x01, 0x01, 0x1e, 0x02, 0x00, 0xff}, 6},
+		{"truncated", []byte{0x02, 0x10, 0x04}, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, pcvalueTableSize(test.table))
+		})
+	}
+}
+
+func TestFuncData(t *testing.T) {
+	goldFiles, err := getGoldenResources()
+	if err != nil || len(goldFiles) == 0 {
+		// Golden folder does not exist
+		t.Skip("No golden files")
+	}
+
+	for _, test := range goldFiles {
+		t.Run("funcdata_"+test, func(t *testing.T) {
+			r := require.New(t)
+
+			fp, err := getTestResourcePath("gold/" + test)
+			r.NoError(err, "Failed to get path to resource")
+			if _, err = os.Stat(fp); os.IsNotExist(err) {
+				// Skip this file because it doesn't exist
+				// t.Skip will cause the parent test to be skipped.
+				fmt.Printf("[SKIPPING TEST] golden fille %s does not exist\n", test)
+				return
+			}
+			f, err := Open(fp)
+			r.NoError(err)
+			defer f.Close()
+
+			ver, err := f.GetCompilerVersion()
+			r.NoError(err)
+			if GoVersionCompare(ver.Name, "go1.5beta1") < 0 {
+				t.Skip("runtime.main is not compiled by the Go compiler before Go 1.5")
+			}
+
+			std, err := f.GetSTDLib()
+			r.NoError(err)
+
+			var fn *Function
+			for _, p := range std {
+				if p.Name != "runtime" {
+					continue
+				}
+				for _, v := range p.Functions {
+					if v.Name == "main" {
+						fn = v
+						break
+					}
+				}
+			}
+			r.NotNil(fn, "runtime.main not found")
+
+			data, err := f.PCData(fn, PCDataStackMapIndex)
+			r.NoError(err)
+			r.NotEmpty(data)
+
+			data, err = f.FuncData(fn, FuncDataLocalsPointerMaps)
+			r.NoError(err)
+			r.NotEmpty(data)
+
+			_, err = f.FuncData(&Function{Offset: fn.Offset + 1}, FuncDataLocalsPointerMaps)
+			r.ErrorIs(err, ErrFunctionNotFound)
+		})
+	}
+}
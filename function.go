@@ -20,7 +20,9 @@ package gore
 import (
 	"debug/gosym"
 	"fmt"
+	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -34,6 +36,12 @@ type Function struct {
 	End uint64 `json:"end"`
 	// PackageName is the name of the Go package the function belongs to.
 	PackageName string `json:"packageName"`
+	// Filename is the path of the source file the function is defined in.
+	Filename string `json:"filename"`
+	// SrcLineStart is the source line of the function's entry point.
+	SrcLineStart int `json:"srcLineStart"`
+	// symbolName is the full name of the function in the symbol table.
+	symbolName string
 }
 
 // String returns a string representation of the function.
@@ -53,6 +61,522 @@ func (m *Method) String() string {
 	return fmt.Sprintf("%s%s", m.Receiver, m.Name)
 }
 
+// Functions returns all the functions in the binary, from all the package classes,
+// sorted by their offset. Methods are included as functions, the receiver can be
+// found by looking up the method in the package.
+func (f *GoFile) Functions() ([]*Function, error) {
+	pkgs, err := f.allPackages()
+	if err != nil {
+		return nil, err
+	}
+
+	var fns []*Function
+	for _, p := range pkgs {
+		fns = append(fns, p.Functions...)
+		for _, m := range p.Methods {
+			fns = append(fns, m.Function)
+		}
+	}
+
+	sort.Slice(fns, func(i, j int) bool {
+		return fns[i].Offset < fns[j].Offset
+	})
+
+	return fns, nil
+}
+
+// WalkFunctions calls fn for each function in the binary, in the order returned
+// by Functions, with the function's code. The code is read when the function
+// is visited, so only the code of one function is held at a time. If fn
+// returns an error, the walk is stopped and the error is returned. The end of
+// a function is where the next function starts, so the code of the last
+// function may be followed by padding or data that isn't part of the function.
+func (f *GoFile) WalkFunctions(fn func(*Function, []byte) error) error {
+	fns, err := f.Functions()
+	if err != nil {
+		return err
+	}
+	for _, fcn := range fns {
+		code, err := f.Bytes(fcn.Offset, fcn.End-fcn.Offset)
+		if err != nil {
+			return fmt.Errorf("failed to read the code of %s: %w", fcn.Name, err)
+		}
+		if err = fn(fcn, code); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FunctionNames returns the full names of all the functions in the PCLN table,
+// ordered by their address. The names are read directly from the table's name
+// data, so this is cheaper than enumerating the packages and it works even if
+// the packages can't be classified, for example if there is no main package.
+func (f *GoFile) FunctionNames() ([]string, error) {
+	err := f.initPclntab()
+	if err != nil {
+		return nil, err
+	}
+	t, err := newPCLNTable(f.pclntabBytes, f.pclntabAddr, f.runtimeText, f.FileInfo.ByteOrder)
+	if err != nil {
+		return nil, err
+	}
+	return t.funcNames()
+}
+
+// InitFunctions returns the package initialization functions in the binary. This
+// includes the "init" functions defined in the source code, which the compiler
+// renames to "init.0", "init.1", etc., and the "init" function generated by the
+// compiler for a package.
+// The functions are sorted by package name and then by the order they are defined
+// in the package. The order between the packages does not reflect the order
+// the runtime executes them in.
+func (f *GoFile) InitFunctions() ([]*Function, error) {
+	pkgs, err := f.allPackages()
+	if err != nil {
+		return nil, err
+	}
+
+	var inits []*Function
+	for _, p := range pkgs {
+		for _, fn := range p.Functions {
+			if fn.Name == "init" {
+				inits = append(inits, fn)
+			}
+		}
+		// The user defined init functions are named for example "main.init.0"
+		// which results in them being treated as methods with "init" as the
+		// receiver.
+		for _, m := range p.Methods {
+			if m.Receiver != "init" {
+				continue
+			}
+			if _, err := strconv.Atoi(m.Name); err != nil {
+				// Function literals in the package scope, for example "init.func1".
+				continue
+			}
+			fn := *m.Function
+			fn.Name = "init." + m.Name
+			inits = append(inits, &fn)
+		}
+	}
+
+	sort.Slice(inits, func(i, j int) bool {
+		if inits[i].PackageName != inits[j].PackageName {
+			return inits[i].PackageName < inits[j].PackageName
+		}
+		return initFunctionIndex(inits[i].Name) < initFunctionIndex(inits[j].Name)
+	})
+
+	return inits, nil
+}
+
+// InitOrder returns the package initialization functions in the order they are
+// executed by the runtime. The order is read from the list of initialization
+// tasks built by the linker, which is only available in binaries compiled with
+// Go 1.21 or later. For older binaries, ErrNoInitTasks is returned. The user
+// defined init functions are named the same way as by InitFunctions, for example
+// "init.0". Addresses in the tasks that are not the start of a function in the
+// PCLN table are skipped, so the result only has the functions that are known.
+func (f *GoFile) InitOrder() ([]*Function, error) {
+	err := f.initModuleData()
+	if err != nil {
+		return nil, err
+	}
+	md := f.moduledata
+	if md.InitTasksAddr == 0 {
+		return nil, ErrNoInitTasks
+	}
+
+	fns, err := f.Functions()
+	if err != nil {
+		return nil, err
+	}
+	byAddr := make(map[uint64]*Function, len(fns))
+	for _, fn := range fns {
+		byAddr[fn.Offset] = fn
+	}
+	inits, err := f.InitFunctions()
+	if err != nil {
+		return nil, err
+	}
+	for _, fn := range inits {
+		byAddr[fn.Offset] = fn
+	}
+
+	ws := uint64(f.FileInfo.WordSize)
+	var order []*Function
+	for i := uint64(0); i < md.InitTasksLen; i++ {
+		task, err := f.ReadPointer(md.InitTasksAddr + i*ws)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read init task %d: %w", i, err)
+		}
+		// The task starts with two uint32 values, the state and the number of
+		// functions. The functions' addresses follow the header.
+		hdr, err := f.Bytes(task, 8)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read init task %d: %w", i, err)
+		}
+		nfns := uint64(f.FileInfo.ByteOrder.Uint32(hdr[4:]))
+		// The number of functions is checked against the rest of the section
+		// so a corrupt task can't make us loop for a long time.
+		base, data, err := f.fh.getSectionDataFromAddress(task)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read init task %d: %w", i, err)
+		}
+		// Bytes can read the header across the end of the section, so it
+		// may not be followed by anything in this one.
+		if task < base || task+8-base > uint64(len(data)) {
+			return nil, fmt.Errorf("init task %d at 0x%x is not within its section", i, task)
+		}
+		if left := uint64(len(data)) - (task + 8 - base); nfns > left/ws {
+			return nil, fmt.Errorf("init task %d has %d functions but the section only has room for %d", i, nfns, left/ws)
+		}
+		for j := uint64(0); j < nfns; j++ {
+			pc, err := f.ReadPointer(task + 8 + j*ws)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read function %d of init task %d: %w", j, i, err)
+			}
+			if fn, ok := byAddr[pc]; ok {
+				order = append(order, fn)
+			}
+		}
+	}
+
+	return order, nil
+}
+
+// ResolveFuncValue returns the function referenced by the function value at
+// the address. A function value is a pointer sized word that holds the entry
+// address of the function. Since Go 1.18, the linker stores the static function
+// values, used for example for closures without captured variables and method
+// values, in the "go:func.*" data that starts at the moduledata's GoFuncValue.
+// If the binary doesn't have the data, ErrNoGoFuncData is returned. If the
+// address is not in the data or the word is not the entry of a function,
+// ErrNotFuncValue is returned.
+func (f *GoFile) ResolveFuncValue(addr uint64) (*Function, error) {
+	err := f.initModuleData()
+	if err != nil {
+		return nil, err
+	}
+	gofunc := f.moduledata.GoFuncVal
+	if gofunc == 0 {
+		return nil, ErrNoGoFuncData
+	}
+	base, data, err := f.fh.getSectionDataFromAddress(gofunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the go:func.* data: %w", err)
+	}
+	if addr < gofunc || addr >= base+uint64(len(data)) {
+		return nil, fmt.Errorf("address 0x%x is outside of the go:func.* data: %w", addr, ErrNotFuncValue)
+	}
+
+	pc, err := f.ReadPointer(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the function value at 0x%x: %w", addr, err)
+	}
+	fns, err := f.Functions()
+	if err != nil {
+		return nil, err
+	}
+	i := sort.Search(len(fns), func(i int) bool {
+		return fns[i].Offset >= pc
+	})
+	if i == len(fns) || fns[i].Offset != pc {
+		return nil, fmt.Errorf("0x%x at 0x%x is not the entry of a function: %w", pc, addr, ErrNotFuncValue)
+	}
+	return fns[i], nil
+}
+
+// SymbolizedAddr is an address resolved to the function and the source line
+// it belongs to.
+type SymbolizedAddr struct {
+	// Address is the resolved address.
+	Address uint64
+	// Function is the function the address is in. It is nil if the address
+	// is not in a function.
+	Function *Function
+	// File is the source file of the code at the address. For inlined code,
+	// it's the file of the inlined function.
+	File string
+	// Line is the source line of the code at the address.
+	Line int
+}
+
+// Symbolize resolves the addresses to the functions and source lines they
+// belong to. The results are returned in the same order as the addresses.
+// The addresses are sorted internally and matched against the sorted
+// functions in a single pass, so this is cheaper than looking up the
+// addresses one by one when resolving many of them, for example the program
+// counters of a profile.
+func (f *GoFile) Symbolize(addrs []uint64) ([]SymbolizedAddr, error) {
+	fns, err := f.Functions()
+	if err != nil {
+		return nil, err
+	}
+
+	order := make([]int, len(addrs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return addrs[order[i]] < addrs[order[j]]
+	})
+
+	result := make([]SymbolizedAddr, len(addrs))
+	next := 0
+	for _, i := range order {
+		addr := addrs[i]
+		for next < len(fns) && fns[next].End <= addr {
+			next++
+		}
+		result[i].Address = addr
+		if next == len(fns) || addr < fns[next].Offset {
+			continue
+		}
+		result[i].Function = fns[next]
+		result[i].File, result[i].Line, _ = f.pclntab.PCToLine(addr)
+	}
+	return result, nil
+}
+
+// MainFunction returns the main function of the main package, "main.main".
+// If the binary does not have a main function, for example if it was built
+// as a shared library, ErrNoMainFunction is returned.
+func (f *GoFile) MainFunction() (*Function, error) {
+	pkgs, err := f.allPackages()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pkgs {
+		// The package of the main function is always named main in the symbol table
+		// but it can be listed under the name "command-line-arguments" if the binary
+		// was built from a list of source files.
+		if p.Name != "main" && p.Name != "command-line-arguments" {
+			continue
+		}
+		for _, fn := range p.Functions {
+			if fn.Name == "main" {
+				return fn, nil
+			}
+		}
+	}
+	return nil, ErrNoMainFunction
+}
+
+// ExportedGoFunctions returns the functions marked with "//export" in a binary
+// built with the "c-shared" or "c-archive" build mode, sorted by their offset.
+// These are the functions a C caller of the library can call. For each
+// exported function, cgo generates a "_cgoexp_<hash>_<name>" function that
+// the exported C symbol calls to enter Go. If the exported function has been
+// inlined into the generated function, the generated function is returned
+// instead. Binaries without exported functions return an empty result.
+func (f *GoFile) ExportedGoFunctions() ([]*Function, error) {
+	pkgs, err := f.allPackages()
+	if err != nil {
+		return nil, err
+	}
+
+	var fns []*Function
+	for _, p := range pkgs {
+		var byName map[string]*Function
+		for _, fn := range p.Functions {
+			name, ok := cgoExportName(fn.Name)
+			if !ok {
+				continue
+			}
+			if byName == nil {
+				byName = make(map[string]*Function, len(p.Functions))
+				for _, fn := range p.Functions {
+					byName[fn.Name] = fn
+				}
+			}
+			if exp, ok := byName[name]; ok {
+				fns = append(fns, exp)
+			} else {
+				fns = append(fns, fn)
+			}
+		}
+	}
+
+	sort.Slice(fns, func(i, j int) bool {
+		return fns[i].Offset < fns[j].Offset
+	})
+
+	return fns, nil
+}
+
+// cgoExportName returns the name of the exported function from the name of
+// the function generated by cgo for it. The name has the form
+// "_cgoexp_<hash>_<name>" where the hash is a hex string.
+func cgoExportName(name string) (string, bool) {
+	rest, ok := strings.CutPrefix(name, "_cgoexp_")
+	if !ok {
+		return "", false
+	}
+	_, exp, ok := strings.Cut(rest, "_")
+	return exp, ok && exp != ""
+}
+
+// MethodsForType returns the compiled methods that have the type as their receiver,
+// sorted by their offset. Both methods with a value receiver and a pointer receiver
+// are included. If the type is a pointer type, the methods for the type it points to
+// are returned. Unnamed types, which can't have methods, return an empty result.
+func (f *GoFile) MethodsForType(t *GoType) ([]*Method, error) {
+	if t.Kind == reflect.Ptr && t.Element != nil {
+		t = t.Element
+	}
+	recv, ok := methodReceiverName(t)
+	if !ok {
+		return nil, nil
+	}
+
+	pkgs, err := f.allPackages()
+	if err != nil {
+		return nil, err
+	}
+
+	var methods []*Method
+	for _, p := range pkgs {
+		if p.Name != t.PackagePath {
+			continue
+		}
+		for _, m := range p.Methods {
+			if m.Receiver == recv || m.Receiver == "(*"+recv+")" {
+				methods = append(methods, m)
+			}
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Offset < methods[j].Offset
+	})
+
+	return methods, nil
+}
+
+// MethodsByReceiver returns all the methods in the binary grouped by their receiver type.
+// The key is the import path of the package followed by the receiver's type name, for
+// example "os.File" or "net/http.Client". Methods with a value receiver and with a pointer
+// receiver are grouped together, so the value is the type's full method set, sorted by
+// offset. Function literals and the methods generated by the compiler are not included.
+func (f *GoFile) MethodsByReceiver() (map[string][]*Method, error) {
+	pkgs, err := f.allPackages()
+	if err != nil {
+		return nil, err
+	}
+
+	methods := make(map[string][]*Method)
+	for _, p := range pkgs {
+		for _, m := range p.Methods {
+			if !isTypeMethod(m) || IsCompilerHelper(m.Function) {
+				continue
+			}
+			recv := strings.TrimSuffix(strings.TrimPrefix(m.Receiver, "(*"), ")")
+			key := p.Name + "." + recv
+			methods[key] = append(methods[key], m)
+		}
+	}
+
+	for _, ms := range methods {
+		sort.Slice(ms, func(i, j int) bool {
+			return ms[i].Offset < ms[j].Offset
+		})
+	}
+
+	return methods, nil
+}
+
+// isTypeMethod returns true if the method is a method of a type. Function literals,
+// for example "main.run.func1", and the user defined init functions, for example
+// "main.init.0", are parsed as methods because of how their symbols are named.
+func isTypeMethod(m *Method) bool {
+	// The receiver of a function literal within a method includes the method name,
+	// for example "(*T).Run" for "main.(*T).Run.func1".
+	if strings.Contains(m.Receiver, ".") {
+		return false
+	}
+	name := strings.TrimPrefix(m.Name, "func")
+	if _, err := strconv.Atoi(name); err == nil {
+		return false
+	}
+	return true
+}
+
+// methodReceiverName returns the receiver name used in the symbol table for methods
+// of the type. The type name is prefixed with the package name, for example "http.Client",
+// while the receiver in the symbol table is only "Client". For generic types, the
+// type arguments are replaced with "...", since the methods are shared between
+// the instantiations.
+func methodReceiverName(t *GoType) (string, bool) {
+	if t.PackagePath == "" {
+		return "", false
+	}
+	_, name, ok := strings.Cut(t.Name, ".")
+	if !ok || name == "" {
+		return "", false
+	}
+	if i := strings.IndexByte(name, '['); i != -1 {
+		name = name[:i] + "[...]"
+	}
+	return name, true
+}
+
+// compilerHelperPrefixes are the symbol name prefixes used for functions generated
+// by the compiler for comparing and hashing types. Go 1.21 changed the "type." prefix
+// to "type:".
+var compilerHelperPrefixes = []string{
+	"type..eq.",
+	"type..hash.",
+	"type:.eq.",
+	"type:.hash.",
+}
+
+// cgoHelperPrefixes are the name prefixes used for the functions generated by cgo.
+var cgoHelperPrefixes = []string{
+	"_Cfunc_",
+	"_Cgo_",
+	"_cgo_",
+	"_cgoexp_",
+	"x_cgo_",
+}
+
+// IsCompilerHelper returns true if the function is a helper generated by the compiler
+// instead of being written by the user. This includes the equality and hash functions
+// generated for types and the thunks generated by cgo. These functions usually dominate
+// the generated functions in a binary so this can be used to hide them from a function
+// listing.
+func IsCompilerHelper(fn *Function) bool {
+	name := fn.symbolName
+	if name == "" {
+		name = fn.Name
+		if fn.PackageName != "" {
+			name = fn.PackageName + "." + name
+		}
+	}
+	for _, prefix := range compilerHelperPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	for _, prefix := range cgoHelperPrefixes {
+		if strings.HasPrefix(fn.Name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// initFunctionIndex returns the sequence number for a user defined init function.
+// The compiler generated package init function is returned as -1.
+func initFunctionIndex(name string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(name, "init."))
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
 // FileEntry is a representation of an entry in a source code file. This can for example be
 // a function or a method.
 type FileEntry struct {
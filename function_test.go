@@ -18,10 +18,15 @@
 package gore
 
 import (
+	"debug/gosym"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStringer(t *testing.T) {
@@ -45,3 +50,289 @@ func TestStringer(t *testing.T) {
 	}
 
 }
+
+func TestMethodsForType(t *testing.T) {
+	f := newTestGoFile(nil, nil)
+
+	newMethod := func(recv, name string, offset uint64) *Method {
+		return &Method{Receiver: recv, Function: &Function{Name: name, Offset: offset, PackageName: "net/http"}}
+	}
+	get := newMethod("(*Client)", "Get", 0x300)
+	do := newMethod("(*Client)", "Do", 0x200)
+	str := newMethod("Header", "Get", 0x100)
+	list := newMethod("(*List[...])", "Push", 0x400)
+	f.pkgs = []*Package{
+		{Name: "net/http", Methods: []*Method{get, str, do, list}},
+		{Name: "main", Methods: []*Method{newMethod("(*Client)", "Get", 0x500)}},
+	}
+
+	client := &GoType{Kind: reflect.Struct, Name: "http.Client", PackagePath: "net/http"}
+
+	tests := []struct {
+		name     string
+		typ      *GoType
+		expected []*Method
+	}{
+		{"pointer receiver", client, []*Method{do, get}},
+		{"pointer type", &GoType{Kind: reflect.Ptr, Name: "*http.Client", Element: client}, []*Method{do, get}},
+		{"value receiver", &GoType{Kind: reflect.Map, Name: "http.Header", PackagePath: "net/http"}, []*Method{str}},
+		{"generic", &GoType{Kind: reflect.Struct, Name: "http.List[int]", PackagePath: "net/http"}, []*Method{list}},
+		{"unnamed", &GoType{Kind: reflect.Slice, Name: "[]int"}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			methods, err := f.MethodsForType(test.typ)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, methods)
+		})
+	}
+}
+
+func TestIsCompilerHelper(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       *Function
+		expected bool
+	}{
+		{"type eq", &Function{Name: "Frame", symbolName: "type..eq.[2]runtime.Frame"}, true},
+		{"type hash", &Function{Name: "Frame", symbolName: "type..hash.runtime.Frame"}, true},
+		{"type eq go1.21", &Function{Name: "Frame", symbolName: "type:.eq.[1]runtime.Frame"}, true},
+		{"type hash go1.21", &Function{Name: "option", symbolName: "type:.hash.internal/cpu.option"}, true},
+		{"cgo call", &Function{Name: "_Cfunc_puts", PackageName: "main", symbolName: "main._Cfunc_puts"}, true},
+		{"cgo export", &Function{Name: "_cgoexp_123abc_Hello", PackageName: "main"}, true},
+		{"cgo runtime", &Function{Name: "_cgo_panic", PackageName: "runtime/cgo"}, true},
+		{"no symbol name", &Function{Name: "Frame", PackageName: "type:.eq.runtime"}, true},
+		{"user function", &Function{Name: "main", PackageName: "main", symbolName: "main.main"}, false},
+		{"runtime equal", &Function{Name: "memequal", PackageName: "runtime", symbolName: "runtime.memequal"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsCompilerHelper(test.fn))
+		})
+	}
+}
+
+func TestMethodsByReceiver(t *testing.T) {
+	f := newTestGoFile(nil, nil)
+
+	newMethod := func(pkg, recv, name string, offset uint64) *Method {
+		return &Method{Receiver: recv, Function: &Function{Name: name, Offset: offset, PackageName: pkg}}
+	}
+	osWrite := newMethod("os", "(*File)", "Write", 0x200)
+	osName := newMethod("os", "(*File)", "Name", 0x100)
+	mainGet := newMethod("main", "(*File)", "Get", 0x300)
+	mainString := newMethod("main", "File", "String", 0x400)
+	f.stdPkgs = []*Package{{Name: "os", Methods: []*Method{osWrite, osName, newMethod("os", "init", "0", 0x500)}}}
+	f.pkgs = []*Package{{Name: "main", Methods: []*Method{
+		mainGet,
+		mainString,
+		newMethod("main", "run", "func1", 0x600),
+		newMethod("main", "(*File).Get", "func1", 0x700),
+	}}}
+
+	methods, err := f.MethodsByReceiver()
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]*Method{
+		"os.File":   {osName, osWrite},
+		"main.File": {mainGet, mainString},
+	}, methods)
+}
+
+func TestInitOrder(t *testing.T) {
+	r := require.New(t)
+
+	// The list has two tasks. The first task has two functions and the second
+	// task has one function and an address that isn't a known function.
+	mem := make([]byte, 0x300)
+	le := binary.LittleEndian
+	le.PutUint64(mem[0x00:], 0x1100)
+	le.PutUint64(mem[0x08:], 0x1200)
+	le.PutUint32(mem[0x104:], 2)
+	le.PutUint64(mem[0x108:], 0x400)
+	le.PutUint64(mem[0x110:], 0x300)
+	le.PutUint32(mem[0x204:], 2)
+	le.PutUint64(mem[0x208:], 0x200)
+	le.PutUint64(mem[0x210:], 0x500)
+
+	fh := &mockFileHandler{
+		mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+			if a < 0x1000 || a >= 0x1000+uint64(len(mem)) {
+				return 0, nil, ErrSectionDoesNotExist
+			}
+			return 0x1000, mem, nil
+		},
+	}
+
+	osInit := &Function{Name: "init", PackageName: "os", Offset: 0x200}
+	mainInit := &Function{Name: "init", PackageName: "main", Offset: 0x300}
+	f := newTestGoFile(fh, &FileInfo{ByteOrder: le, WordSize: intSize64}, &Package{Name: "main", Functions: []*Function{mainInit}, Methods: []*Method{
+		{Receiver: "init", Function: &Function{Name: "0", PackageName: "main", Offset: 0x400}},
+	}})
+	f.stdPkgs = []*Package{{Name: "os", Functions: []*Function{osInit}}}
+
+	_, err := f.InitOrder()
+	r.ErrorIs(err, ErrNoInitTasks)
+
+	f.moduledata.InitTasksAddr = 0x1000
+	f.moduledata.InitTasksLen = 2
+	fns, err := f.InitOrder()
+	r.NoError(err)
+	r.Len(fns, 3)
+	r.Equal("init.0", fns[0].Name)
+	r.Equal(uint64(0x400), fns[0].Offset)
+	r.Equal(mainInit, fns[1])
+	r.Equal(osInit, fns[2])
+
+	// A number of functions larger than the section is rejected.
+	le.PutUint32(mem[0x204:], 0xffffffff)
+	_, err = f.InitOrder()
+	r.ErrorContains(err, "init task 1 has 4294967295 functions")
+
+	// A task header that ends in the next section leaves no room for the
+	// functions in its own section.
+	next := make([]byte, 0x100)
+	le.PutUint32(next[0x00:], 0xffffffff)
+	fh.mGetSectionDataFromAddress = func(a uint64) (uint64, []byte, error) {
+		switch {
+		case a >= 0x1000 && a < 0x1000+uint64(len(mem)):
+			return 0x1000, mem, nil
+		case a >= 0x1300 && a < 0x1300+uint64(len(next)):
+			return 0x1300, next, nil
+		}
+		return 0, nil, ErrSectionDoesNotExist
+	}
+	le.PutUint64(mem[0x08:], 0x12fc)
+	_, err = f.InitOrder()
+	r.ErrorContains(err, "init task 1 at 0x12fc is not within its section")
+}
+
+func TestResolveFuncValue(t *testing.T) {
+	r := require.New(t)
+
+	// The go:func.* data starts after other read-only data and holds two
+	// function values, one for a function and one for a method.
+	mem := make([]byte, 0x100)
+	le := binary.LittleEndian
+	le.PutUint64(mem[0x40:], 0x200)
+	le.PutUint64(mem[0x48:], 0x300)
+	le.PutUint64(mem[0x50:], 0x210)
+
+	fh := &mockFileHandler{
+		mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+			if a < 0x1000 || a >= 0x1000+uint64(len(mem)) {
+				return 0, nil, ErrSectionDoesNotExist
+			}
+			return 0x1000, mem, nil
+		},
+	}
+
+	fn := &Function{Name: "run", PackageName: "main", Offset: 0x200, End: 0x220}
+	m := &Method{Receiver: "(*T)", Function: &Function{Name: "Run-fm", PackageName: "main", Offset: 0x300}}
+	f := newTestGoFile(fh, &FileInfo{ByteOrder: le, WordSize: intSize64}, &Package{
+		Name:      "main",
+		Functions: []*Function{fn},
+		Methods:   []*Method{m},
+	})
+
+	_, err := f.ResolveFuncValue(0x1040)
+	r.ErrorIs(err, ErrNoGoFuncData)
+
+	f.moduledata.GoFuncVal = 0x1040
+	got, err := f.ResolveFuncValue(0x1040)
+	r.NoError(err)
+	r.Equal(fn, got)
+
+	got, err = f.ResolveFuncValue(0x1048)
+	r.NoError(err)
+	r.Equal(m.Function, got)
+
+	_, err = f.ResolveFuncValue(0x1050)
+	r.ErrorIs(err, ErrNotFuncValue, "not the entry of the function")
+
+	_, err = f.ResolveFuncValue(0x1038)
+	r.ErrorIs(err, ErrNotFuncValue, "before the go:func.* data")
+
+	_, err = f.ResolveFuncValue(0x1100)
+	r.ErrorIs(err, ErrNotFuncValue, "after the section")
+}
+
+func TestExportedGoFunctions(t *testing.T) {
+	hello := &Function{Name: "Hello", PackageName: "main", Offset: 0x300}
+	inlined := &Function{Name: "_cgoexp_123abc_Add_One", PackageName: "main", Offset: 0x200}
+	f := newTestGoFile(nil, nil, &Package{Name: "main", Functions: []*Function{
+		{Name: "main", PackageName: "main", Offset: 0x100},
+		hello,
+		{Name: "_cgoexp_123abc_Hello", PackageName: "main", Offset: 0x400},
+		inlined,
+		{Name: "_cgoexp_", PackageName: "main", Offset: 0x500},
+	}})
+	f.stdPkgs = []*Package{{Name: "runtime/cgo", Functions: []*Function{
+		{Name: "_cgo_panic", PackageName: "runtime/cgo", Offset: 0x600},
+	}}}
+
+	fns, err := f.ExportedGoFunctions()
+	require.NoError(t, err)
+	assert.Equal(t, []*Function{inlined, hello}, fns)
+}
+
+func TestWalkFunctions(t *testing.T) {
+	code := []byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6}
+	fh := &mockFileHandler{
+		mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+			if a < 0x1000 || a >= 0x1000+uint64(len(code)) {
+				return 0, nil, errors.New("out of bound")
+			}
+			return 0x1000, code, nil
+		},
+	}
+	f := newTestGoFile(fh, nil, &Package{Name: "main", Functions: []*Function{
+		{Name: "b", PackageName: "main", Offset: 0x1004, End: 0x1006},
+		{Name: "a", PackageName: "main", Offset: 0x1000, End: 0x1004},
+	}})
+
+	var names []string
+	var bodies [][]byte
+	err := f.WalkFunctions(func(fn *Function, b []byte) error {
+		names = append(names, fn.Name)
+		bodies = append(bodies, b)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, names)
+	assert.Equal(t, [][]byte{{0x1, 0x2, 0x3, 0x4}, {0x5, 0x6}}, bodies)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = f.WalkFunctions(func(*Function, []byte) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestSymbolize(t *testing.T) {
+	r := require.New(t)
+	a := &Function{Name: "a", PackageName: "main", Offset: 0x1000, End: 0x1010}
+	b := &Function{Name: "b", PackageName: "main", Offset: 0x1010, End: 0x1020}
+	c := &Function{Name: "c", PackageName: "main", Offset: 0x1040, End: 0x1050}
+	f := newTestGoFile(nil, nil, &Package{Name: "main", Functions: []*Function{c, a, b}})
+	f.pclntab = &gosym.Table{}
+
+	addrs := []uint64{0x1048, 0x1000, 0x1030, 0x100f, 0x1010, 0x2000, 0x1000}
+	syms, err := f.Symbolize(addrs)
+	r.NoError(err)
+	r.Len(syms, len(addrs))
+
+	expected := []*Function{c, a, nil, a, b, nil, a}
+	for i, sym := range syms {
+		r.Equal(addrs[i], sym.Address)
+		r.Same(expected[i], sym.Function, "address 0x%x", addrs[i])
+	}
+
+	syms, err = f.Symbolize(nil)
+	r.NoError(err)
+	r.Empty(syms)
+}
@@ -258,7 +258,7 @@ func (g *moduleDataGenerator) writeVersionedModuleData(versionCode int, code str
 			}
 
 			for _, name := range field.Names {
-				if name.Name == "modulename" {
+				if _, ok := knownFields["modulehashes"]; ok {
 					// no more data needed
 					break search
 				}
@@ -331,6 +331,11 @@ func (g *moduleDataGenerator) writeVersionedModuleData(versionCode int, code str
 			g.writeln("TypesLen: %s,", g.wrapValue("md.Etypes - md.Types", bits))
 		}
 
+		if exist("textsectmap") {
+			g.writeln("TextSectMapAddr: %s,", g.wrapValue("md.Textsectmap", bits))
+			g.writeln("TextSectMapLen: %s,", g.wrapValue("md.Textsectmaplen", bits))
+		}
+
 		if exist("typelinks") {
 			g.writeln("TypelinkAddr: %s,", g.wrapValue("md.Typelinks", bits))
 			g.writeln("TypelinkLen: %s,", g.wrapValue("md.Typelinkslen", bits))
@@ -355,6 +360,21 @@ func (g *moduleDataGenerator) writeVersionedModuleData(versionCode int, code str
 			g.writeln("GoFuncVal: %s,", g.wrapValue("md.Gofunc", bits))
 		}
 
+		if exist("pkghashes") {
+			g.writeln("PkgHashesAddr: %s,", g.wrapValue("md.Pkghashes", bits))
+			g.writeln("PkgHashesLen: %s,", g.wrapValue("md.Pkghasheslen", bits))
+		}
+
+		if exist("modulehashes") {
+			g.writeln("ModuleHashesAddr: %s,", g.wrapValue("md.Modulehashes", bits))
+			g.writeln("ModuleHashesLen: %s,", g.wrapValue("md.Modulehasheslen", bits))
+		}
+
+		if exist("inittasks") {
+			g.writeln("InitTasksAddr: %s,", g.wrapValue("md.Inittasks", bits))
+			g.writeln("InitTasksLen: %s,", g.wrapValue("md.Inittaskslen", bits))
+		}
+
 		g.writeln("}\n}\n")
 	}
 
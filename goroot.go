@@ -18,25 +18,24 @@
 package gore
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
-	"reflect"
 	"strings"
 	"unicode/utf8"
 
 	"golang.org/x/arch/x86/x86asm"
 )
 
+// isGoRootString is used to filter out strings that can't be a GOROOT path.
+func isGoRootString(_ uint64, data []byte) bool {
+	return len(data) > 0 && utf8.Valid(data)
+}
+
 func tryFromGOROOT(f *GoFile) (string, error) {
 	// Check for non-supported architectures.
-	if f.FileInfo.Arch != Arch386 && f.FileInfo.Arch != ArchAMD64 {
-		return "", nil
-	}
-
-	is32 := false
-	if f.FileInfo.Arch == Arch386 {
-		is32 = true
+	d := newArchDisassembler(f, movRAXStringLoad, isGoRootString)
+	if d == nil {
+		return "", ErrUnsupportedArch
 	}
 
 	// Find runtime.GOROOT function.
@@ -70,77 +69,27 @@ pkgLoop:
 	if err != nil {
 		return "", nil
 	}
-	s := 0
-	mode := f.FileInfo.WordSize * 8
-
-	for s < len(buf) {
-		inst, err := x86asm.Decode(buf[s:], mode)
-		if err != nil {
-			// If we fail to decode the instruction, something is wrong so
-			// bailout.
-			return "", nil
-		}
-
-		// Update next instruction location.
-		s = s + inst.Len
-
-		// Check if it's a "mov" instruction.
-		if inst.Op != x86asm.MOV {
-			continue
-		}
-		if inst.Args[0] != x86asm.RAX && inst.Args[0] != x86asm.EAX {
-			continue
-		}
-		arg := inst.Args[1].(x86asm.Mem)
-
-		// First, assume that the address is a direct addressing.
-		addr := arg.Disp
-		if arg.Base == x86asm.EIP || arg.Base == x86asm.RIP {
-			// If the addressing is based on the instruction pointer, fix the address.
-			addr = addr + int64(fcn.Offset) + int64(s)
-		} else if arg.Base == 0 && arg.Disp > 0 {
-			// To support x32 direct addressing
-		} else {
-			continue
-		}
-		// If the addressing is based on the stack pointer, this is not the right
-		// instruction.
-		if arg.Base == x86asm.ESP || arg.Base == x86asm.RSP {
-			continue
-		}
-
-		// Resolve the pointer to the string. If we get no data, this is not the
-		// right instruction.
-		b, _ := f.Bytes(uint64(addr), uint64(0x20))
-		if b == nil {
-			continue
-		}
 
-		r := bytes.NewReader(b)
-		ptr, err := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
+	if ptr, l, ok := d.ResolveStringLoad(fcn, buf); ok {
+		bstr, err := f.Bytes(ptr, l)
 		if err != nil {
-			// Probably not the right instruction, so go to next.
-			continue
-		}
-		l, err := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
-		if err != nil {
-			// Probably not the right instruction, so go to next.
-			continue
-		}
-
-		bstr, _ := f.Bytes(ptr, l)
-		if bstr == nil {
-			continue
-		}
-		ver := string(bstr)
-		if !utf8.ValidString(ver) {
 			return "", ErrNoGoRootFound
 		}
-		return ver, nil
+		return string(bstr), nil
 	}
 
-	// for go version vary from 1.5 to 1.9
-	s = 0
+	// Go 1.5 to 1.9 is only supported for x86.
+	if _, ok := d.(*x86Disassembler); !ok {
+		return "", ErrNoGoRootFound
+	}
+	return tryFromGOROOTLegacy(f, fcn, buf)
+}
+
+// tryFromGOROOTLegacy extracts the GOROOT path from the runtime.GOROOT function
+// in binaries compiled with Go 1.5 to 1.9.
+func tryFromGOROOTLegacy(f *GoFile, fcn *Function, buf []byte) (string, error) {
+	s := 0
+	mode := f.FileInfo.WordSize * 8
 	var insts []x86asm.Inst
 	for s < len(buf) {
 		inst, err := x86asm.Decode(buf[s:], mode)
@@ -210,13 +159,9 @@ pkgLoop:
 
 func tryFromTimeInit(f *GoFile) (string, error) {
 	// Check for non-supported architectures.
-	if f.FileInfo.Arch != Arch386 && f.FileInfo.Arch != ArchAMD64 {
-		return "", nil
-	}
-
-	is32 := false
-	if f.FileInfo.Arch == Arch386 {
-		is32 = true
+	d := newArchDisassembler(f, movRAXOrECXStringLoad, isGoRootString)
+	if d == nil {
+		return "", ErrUnsupportedArch
 	}
 
 	// Find time.initPackages function.
@@ -250,79 +195,16 @@ pkgLoop:
 	if err != nil {
 		return "", nil
 	}
-	s := 0
-	mode := f.FileInfo.WordSize * 8
-
-	for s < len(buf) {
-		inst, err := x86asm.Decode(buf[s:], mode)
-		if err != nil {
-			// If we fail to decode the instruction, something is wrong so
-			// bailout.
-			return "", nil
-		}
-
-		// Update next instruction location.
-		s = s + inst.Len
-
-		// Check if it's a "mov" instruction.
-		if inst.Op != x86asm.MOV {
-			continue
-		}
-		if inst.Args[0] != x86asm.RAX && inst.Args[0] != x86asm.ECX {
-			continue
-		}
-		kindof := reflect.TypeOf(inst.Args[1])
-		if kindof.String() != "x86asm.Mem" {
-			continue
-		}
-		arg := inst.Args[1].(x86asm.Mem)
-
-		// First, assume that the address is a direct addressing.
-		addr := arg.Disp
-		if arg.Base == x86asm.EIP || arg.Base == x86asm.RIP {
-			// If the addressing is based on the instruction pointer, fix the address.
-			addr = addr + int64(fcn.Offset) + int64(s)
-		} else if arg.Base == 0 && arg.Disp > 0 {
-			// To support x32 direct addressing
-		} else {
-			continue
-		}
-		// Resolve the pointer to the string. If we get no data, this is not the
-		// right instruction.
-		b, _ := f.Bytes(uint64(addr), uint64(0x20))
-		if b == nil {
-			continue
-		}
-
-		r := bytes.NewReader(b)
-		ptr, err := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
-		if err != nil {
-			// Probably not the right instruction, so go to next.
-			continue
-		}
-
-		// If the pointer is nil, it's not the right instruction
-		if ptr == 0 {
-			continue
-		}
-
-		l, err := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
-		if err != nil {
-			// Probably not the right instruction, so go to next.
-			continue
-		}
 
-		bstr, _ := f.Bytes(ptr, l)
-		if bstr == nil {
-			continue
-		}
-		ver := string(bstr)
-		if !utf8.ValidString(ver) {
-			return "", ErrNoGoRootFound
-		}
-		return ver, nil
+	ptr, l, ok := d.ResolveStringLoad(fcn, buf)
+	if !ok {
+		return "", ErrNoGoRootFound
 	}
-	return "", ErrNoGoRootFound
+	bstr, err := f.Bytes(ptr, l)
+	if err != nil {
+		return "", ErrNoGoRootFound
+	}
+	return string(bstr), nil
 }
 
 func findGoRootPath(f *GoFile) (string, error) {
@@ -337,7 +219,10 @@ func findGoRootPath(f *GoFile) (string, error) {
 	if goroot != "" {
 		return goroot, nil
 	}
-	if err != nil && !errors.Is(err, ErrNoGoRootFound) {
+	// If the code can't be disassembled, the path may still be found from
+	// the standard library packages below.
+	unsupported := errors.Is(err, ErrUnsupportedArch)
+	if err != nil && !unsupported && !errors.Is(err, ErrNoGoRootFound) {
 		return "", err
 	}
 
@@ -345,7 +230,7 @@ func findGoRootPath(f *GoFile) (string, error) {
 	if goroot != "" {
 		return goroot, nil
 	}
-	if err != nil && !errors.Is(err, ErrNoGoRootFound) {
+	if err != nil && !errors.Is(err, ErrUnsupportedArch) && !errors.Is(err, ErrNoGoRootFound) {
 		return "", err
 	}
 
@@ -369,5 +254,8 @@ func findGoRootPath(f *GoFile) (string, error) {
 		}
 	}
 
+	if unsupported {
+		return "", ErrUnsupportedArch
+	}
 	return "", ErrNoGoRootFound
 }
@@ -18,6 +18,8 @@
 package gore
 
 import (
+	"debug/dwarf"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -62,3 +64,21 @@ func TestExtractGoRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestGoRootUnsupportedArch(t *testing.T) {
+	r := require.New(t)
+	fh := &mockFileHandler{
+		mGetDwarf: func() (*dwarf.Data, error) { return nil, errors.New("no dwarf") },
+	}
+	f := newTestGoFile(fh, &FileInfo{Arch: ArchMIPS, WordSize: intSize32})
+	f.stdPkgs = []*Package{{Name: "runtime", Filepath: "runtime"}}
+
+	_, err := findGoRootPath(f)
+	r.ErrorIs(err, ErrUnsupportedArch)
+
+	// The path is still found from the standard library packages.
+	f.stdPkgs = []*Package{{Name: "runtime", Filepath: "/usr/local/go/src/runtime"}}
+	goroot, err := findGoRootPath(f)
+	r.NoError(err)
+	r.Equal("/usr/local/go", goroot)
+}
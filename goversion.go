@@ -21,10 +21,11 @@ package gore
 
 import (
 	"bytes"
+	"debug/gosym"
 	"errors"
 	"regexp"
-
-	"golang.org/x/arch/x86/x86asm"
+	"strings"
+	"time"
 
 	"github.com/goretk/gore/extern"
 	"github.com/goretk/gore/extern/gover"
@@ -32,6 +33,30 @@ import (
 
 var goVersionMatcher = regexp.MustCompile(`(go[\d+.]*(beta|rc)?[\d*])`)
 
+var develVersionMatcher = regexp.MustCompile(`devel (go1\.\d+-|\+)[0-9a-f]{7,40} [A-Z][a-z]{2} [A-Z][a-z]{2} +\d{1,2} \d{2}:\d{2}:\d{2} \d{4} [+-]\d{4}`)
+
+// Sources the compiler version can be determined from, as returned by
+// CompilerVersionSource.
+const (
+	// VersionSourceBuildInfo is used when the version was read from the
+	// buildinfo structure.
+	VersionSourceBuildInfo = "buildinfo"
+	// VersionSourceDwarf is used when the version was read from the DWARF
+	// debug information.
+	VersionSourceDwarf = "dwarf"
+	// VersionSourceSymbol is used when the version was read from the
+	// runtime.buildVersion variable located by the symbol table.
+	VersionSourceSymbol = "symbol"
+	// VersionSourceSchedInit is used when the version string was found via
+	// the reference in the runtime.schedinit function.
+	VersionSourceSchedInit = "schedinit"
+	// VersionSourceScan is used when the version string was found by
+	// scanning the binary's data.
+	VersionSourceScan = "scan"
+	// VersionSourceUser is used when the version was set with SetGoVersion.
+	VersionSourceUser = "user"
+)
+
 // GoVersion holds information about the compiler version.
 type GoVersion struct {
 	// Name is a string representation of the version.
@@ -45,38 +70,241 @@ type GoVersion struct {
 // ResolveGoVersion tries to return the GoVersion for the given tag.
 // For example the tag: go1 will return a GoVersion struct representing version 1.0 of the compiler.
 // If no goversion for the given tag is found, nil is returned.
+// Version strings of development builds, for example "devel go1.22-8f3f22e3a4 Tue Oct 3 16:12:57 2023 +0000",
+// are resolved to the release the build was made from if the commit is a release, otherwise a GoVersion
+// with the version under development as the name is returned.
 func ResolveGoVersion(tag string) *GoVersion {
 	v, ok := goversions[tag]
 	if !ok {
-		return nil
+		return resolveDevelVersion(tag)
 	}
 	return v
 }
 
+// develTimeLayout is the layout of the commit time in the version string of development builds.
+const develTimeLayout = "Mon Jan 2 15:04:05 2006 -0700"
+
+// resolveDevelVersion returns the GoVersion for a development build's version string. Since
+// Go 1.17 the string has the format "devel go1.17-<sha> <date>" while older versions use
+// "devel +<sha> <date>". The older format does not include the version under development so
+// it can only be resolved if the commit is a release. Nil is returned if the string can't
+// be resolved.
+func resolveDevelVersion(tag string) *GoVersion {
+	fields := strings.Fields(tag)
+	if len(fields) < 2 || fields[0] != "devel" {
+		return nil
+	}
+
+	var base, sha string
+	if strings.HasPrefix(fields[1], "+") {
+		sha = fields[1][1:]
+	} else {
+		base, sha, _ = strings.Cut(fields[1], "-")
+		if !strings.HasPrefix(base, "go1.") || gover.Parse(extern.StripGo(base)) == (gover.Version{}) {
+			return nil
+		}
+	}
+
+	if v := ResolveGoVersionBySHA(sha); v != nil {
+		return v
+	}
+	if base == "" {
+		return nil
+	}
+
+	return &GoVersion{Name: base, SHA: sha, Timestamp: develTimestamp(fields)}
+}
+
+// develTimestamp returns the commit time in the fields of a development build's
+// version string, formatted as RFC 3339. An empty string is returned if the
+// fields don't have the time.
+func develTimestamp(fields []string) string {
+	// The date may be followed by additional information, like the experiments enabled.
+	if len(fields) < 8 {
+		return ""
+	}
+	t, err := time.Parse(develTimeLayout, strings.Join(fields[2:8], " "))
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// develVersionMarkers are packages and functions that were added to the
+// runtime, or the packages it depends on, in the given release. The newest
+// release is listed first.
+var develVersionMarkers = []struct {
+	version string
+	pkg     string
+	fn      string
+}{
+	{"go1.14", "runtime", "asyncPreempt"},
+	{"go1.11", "internal/bytealg", ""},
+	{"go1.7", "runtime", "typelinksinit"},
+}
+
+// develVersionFromFuncs returns the oldest release that has the functions
+// in the runtime. An empty string is returned if no marker function is found.
+func develVersionFromFuncs(fns []gosym.Func) string {
+	for _, m := range develVersionMarkers {
+		for _, fn := range fns {
+			if fn.PackageName() == m.pkg && (m.fn == "" || fn.BaseName() == m.fn) {
+				return m.version
+			}
+		}
+	}
+	return ""
+}
+
+// develVersionFromLayout returns the version for a development build with a
+// version string in the old format, "devel +<sha> <date>", where the commit is
+// not a release. The version under development is not part of the string so
+// the minor version is derived from the format of the PCLN table instead. For
+// the table format used by Go 1.2 to Go 1.15, the functions in the runtime are
+// used to narrow it down. The returned version is the oldest release with the
+// same layout, since the build can be of any commit after it. Nil is returned
+// if the minor version can't be determined.
+func develVersionFromLayout(f *GoFile, tag string) *GoVersion {
+	fields := strings.Fields(tag)
+	if len(fields) < 2 || fields[0] != "devel" || !strings.HasPrefix(fields[1], "+") {
+		return nil
+	}
+
+	tabVer, err := f.PCLNTabVersion()
+	if err != nil {
+		return nil
+	}
+
+	name := "go" + tabVer
+	if tabVer == "1.2" {
+		tab, err := f.PCLNTab()
+		if err != nil {
+			return nil
+		}
+		name = develVersionFromFuncs(tab.Funcs)
+		if name == "" {
+			return nil
+		}
+	}
+
+	return &GoVersion{Name: name, SHA: fields[1][1:], Timestamp: develTimestamp(fields)}
+}
+
+// ResolveGoVersionBySHA returns the GoVersion for the release with the given git commit.
+// The SHA can be abbreviated, for example as in the version string reported by
+// development builds. If no release matches the SHA, or if an abbreviated SHA
+// matches more than one release, nil is returned.
+func ResolveGoVersionBySHA(sha string) *GoVersion {
+	sha = strings.ToLower(sha)
+	if sha == "" {
+		return nil
+	}
+	var match *GoVersion
+	for _, v := range goversions {
+		if !strings.HasPrefix(v.SHA, sha) {
+			continue
+		}
+		if match != nil {
+			// Ambiguous abbreviation.
+			return nil
+		}
+		match = v
+	}
+	return match
+}
+
 // GoVersionCompare compares two version strings.
 // If a < b, -1 is returned.
 // If a == b, 0 is returned.
 // If a > b, 1 is returned.
+// A version without a patch level is the same as the ".0" patch release, so
+// "go1.21" and "go1.21.0" are equal and the release candidates of Go 1.21 are
+// older than both.
 func GoVersionCompare(a, b string) int {
 	if a == b {
 		return 0
 	}
-	a = extern.StripGo(a)
-	b = extern.StripGo(b)
+	a = withPatchLevel(extern.StripGo(a))
+	b = withPatchLevel(extern.StripGo(b))
 	return gover.Compare(a, b)
 }
 
-func findGoCompilerVersion(f *GoFile) (*GoVersion, error) {
+// withPatchLevel adds the ".0" patch level to a release version without one.
+// Since Go 1.21, the first release of a version is named with the ".0" patch
+// level while gover treats the name without it as an older language version.
+func withPatchLevel(v string) string {
+	if p := gover.Parse(v); p.Minor != "" && p.Patch == "" && p.Kind == "" {
+		return v + ".0"
+	}
+	return v
+}
+
+// VersionConsistency compares the compiler version stored in the buildinfo
+// structure with the version found in the rest of the binary, for example the
+// version string referenced by the runtime. The buildinfo version can be
+// modified without affecting the binary's execution, so a mismatch is a sign
+// that the binary has been tampered with. Only the versions are compared, the
+// extra information that can follow them, like the enabled experiments, is
+// dropped from the returned versions. The buildinfo version is read from the
+// structure itself, so it's not affected by where BuildInfo was read from. If
+// the binary has no buildinfo structure, ErrNoBuildInfo is returned.
+func (f *GoFile) VersionConsistency() (consistent bool, buildinfoVer, scannedVer string, err error) {
+	raw, err := f.BuildInfoRaw()
+	if err != nil {
+		return false, "", "", err
+	}
+	buildinfoVer = versionField(string(raw.Version))
+	if buildinfoVer == "" {
+		return false, "", "", ErrNoBuildInfo
+	}
+
+	ver, _, err := findGoCompilerVersion(f)
+	if err != nil {
+		return false, buildinfoVer, "", err
+	}
+	if ver == nil {
+		return false, buildinfoVer, "", ErrNoGoVersionFound
+	}
+	scannedVer = versionField(ver.Name)
+
+	// The versions are compared with the patch level added to versions
+	// without one.
+	consistent = GoVersionCompare(buildinfoVer, scannedVer) == 0
+	return consistent, buildinfoVer, scannedVer, nil
+}
+
+// versionField returns the version from a version string that can have extra
+// information after the version, for example "go1.21.0 X:loopvar".
+func versionField(v string) string {
+	if fields := strings.Fields(v); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// findGoCompilerVersion returns the compiler version and the source it was
+// determined from.
+func findGoCompilerVersion(f *GoFile) (*GoVersion, string, error) {
 	// if DWARF debug info exists, then this can simply be obtained from there
 	if gover, ok := getBuildVersionFromDwarf(f.fh); ok {
 		if ver := ResolveGoVersion(gover); ver != nil {
-			return ver, nil
+			return ver, VersionSourceDwarf, nil
+		}
+	}
+
+	// If the binary has a symbol table, the variable holding the version can
+	// be read directly.
+	if v, err := f.readStringSymbol("runtime.buildVersion"); err == nil {
+		if fields := strings.Fields(v); len(fields) > 0 {
+			if ver := ResolveGoVersion(fields[0]); ver != nil {
+				return ver, VersionSourceSymbol, nil
+			}
 		}
 	}
 
 	// Try to determine the version based on the schedinit function.
 	if v := tryFromSchedInit(f); v != nil {
-		return v, nil
+		return v, VersionSourceSchedInit, nil
 	}
 
 	// If no version was found, search the sections for the
@@ -88,13 +316,24 @@ func findGoCompilerVersion(f *GoFile) (*GoVersion, error) {
 		_, data, err = f.fh.getCodeSection()
 	}
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	// Development builds have a version string that is unlikely to match by chance
+	// so check for it first.
+	if devel := develVersionMatcher.Find(data); devel != nil {
+		if ver := resolveDevelVersion(string(devel)); ver != nil {
+			return ver, VersionSourceScan, nil
+		}
+		if ver := develVersionFromLayout(f, string(devel)); ver != nil {
+			return ver, VersionSourceScan, nil
+		}
 	}
 
 	for {
 		version := matchGoVersionString(data)
 		if version == "" {
-			return nil, ErrNoGoVersionFound
+			return nil, "", ErrNoGoVersionFound
 		}
 		ver := ResolveGoVersion(version)
 		// Go before 1.4 does not have the version string, so if we have found
@@ -108,9 +347,9 @@ func findGoCompilerVersion(f *GoFile) (*GoVersion, error) {
 			data = data[off+2:]
 			continue
 		}
-		return ver, nil
+		return ver, VersionSourceScan, nil
 	}
-	return nil, nil
+	return nil, "", nil
 }
 
 // tryFromSchedInit tries to identify the version of the Go compiler that compiled the code.
@@ -119,24 +358,18 @@ func findGoCompilerVersion(f *GoFile) (*GoVersion, error) {
 // The function returns nil if no version is found.
 func tryFromSchedInit(f *GoFile) *GoVersion {
 	// Check for non-supported architectures.
-	if f.FileInfo.Arch != Arch386 && f.FileInfo.Arch != ArchAMD64 {
+	d := newArchDisassembler(f, leaStringLoad, isGoVersionString)
+	if d == nil {
 		return nil
 	}
 
-	var addr, size uint64
 	var fcn *Function
 	var std []*Package
 	var err error
 
-	is32 := false
-	if f.FileInfo.Arch == Arch386 {
-		is32 = true
-	}
-
 	sym, err := f.fh.getSymbol("runtime.schedinit")
 	if err == nil {
-		addr = sym.Value
-		size = sym.Size
+		fcn = &Function{Name: "schedinit", Offset: sym.Value, End: sym.Value + sym.Size, PackageName: "runtime"}
 		goto disasm
 	}
 
@@ -165,96 +398,44 @@ pkgLoop:
 		// If we can't find the function, there is nothing to do.
 		return nil
 	}
-	addr = fcn.Offset
-	size = fcn.End - fcn.Offset
 
 disasm:
 	// Get the raw hex.
-	buf, err := f.Bytes(addr, size)
+	buf, err := f.Bytes(fcn.Offset, fcn.End-fcn.Offset)
 	if err != nil {
 		return nil
 	}
 
-	/*
-		Disassemble the function until the loading of the Go version is found.
-	*/
-
-	// Counter for how many bytes has been read.
-	s := 0
-	mode := f.FileInfo.WordSize * 8
-
-	for s < len(buf) {
-		inst, err := x86asm.Decode(buf[s:], mode)
-		if err != nil {
-			// If we fail to decode the instruction, something is wrong so
-			// bailout.
-			return nil
-		}
-
-		// Update next instruction location.
-		s = s + inst.Len
-
-		// Check if it's a "lea" instruction.
-		if inst.Op != x86asm.LEA {
-			continue
-		}
-
-		// Check what it's loading and if it's pointing to the compiler version used.
-		// First assume that the address is a direct addressing.
-		arg := inst.Args[1].(x86asm.Mem)
-		disp := arg.Disp
-		if arg.Base == x86asm.EIP || arg.Base == x86asm.RIP {
-			// If the addressing is based on the instruction pointer, fix the address.
-			disp += int64(addr) + int64(s)
-		}
-
-		// If the addressing is based on the stack pointer, this is not the right
-		// instruction.
-		if arg.Base == x86asm.ESP || arg.Base == x86asm.RSP {
-			continue
-		}
-
-		// Resolve the pointer to the string. If we get no data, this is not the
-		// right instruction.
-		b, _ := f.Bytes(uint64(disp), uint64(0x20))
-		if b == nil {
-			continue
-		}
-
-		r := bytes.NewReader(b)
-		ptr, err := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
-		if err != nil {
-			// Probably not the right instruction, so go to next.
-			continue
-		}
-		l, err := readUIntTo64(r, f.FileInfo.ByteOrder, is32)
-		if err != nil {
-			// Probably not the right instruction, so go to next.
-			continue
-		}
-
-		bstr, _ := f.Bytes(ptr, l)
-		if bstr == nil {
-			continue
-		}
-
-		if !bytes.HasPrefix(bstr, []byte("go1.")) {
-			continue
-		}
+	// Disassemble the function until the loading of the Go version is found.
+	ptr, l, ok := d.ResolveStringLoad(fcn, buf)
+	if !ok {
+		return nil
+	}
+	bstr, err := f.Bytes(ptr, l)
+	if err != nil {
+		return nil
+	}
 
-		// Likely the version string.
-		ver := string(bstr)
+	// Likely the version string.
+	ver := string(bstr)
 
-		resolvedVer := ResolveGoVersion(ver)
-		if resolvedVer != nil {
-			return resolvedVer
-		}
+	resolvedVer := ResolveGoVersion(ver)
+	if resolvedVer != nil {
+		return resolvedVer
+	}
 
-		// An unknown version.
-		return &GoVersion{Name: ver}
+	if strings.HasPrefix(ver, "devel ") {
+		// A development build that can't be resolved to a version.
+		return nil
 	}
 
-	return nil
+	// An unknown version.
+	return &GoVersion{Name: ver}
+}
+
+// isGoVersionString is used to filter out strings that can't be the runtime's version string.
+func isGoVersionString(_ uint64, data []byte) bool {
+	return bytes.HasPrefix(data, []byte("go1.")) || bytes.HasPrefix(data, []byte("devel "))
 }
 
 func matchGoVersionString(data []byte) string {
@@ -18,8 +18,13 @@
 package gore
 
 import (
+	"debug/dwarf"
+	"debug/gosym"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"runtime/debug"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,6 +59,142 @@ func TestResolvingVersionFromTag(t *testing.T) {
 	}
 }
 
+func TestResolvingVersionFromSHA(t *testing.T) {
+	tests := []struct {
+		name     string
+		sha      string
+		expected string
+	}{
+		{"full", "205f850ceacfc39d1e9d76a9569416284594ce8c", "go1.1"},
+		{"abbreviated", "205f850cea", "go1.1"},
+		{"upper case", "205F850CEA", "go1.1"},
+		{"unknown", "0000000000", ""},
+		{"empty", "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := ResolveGoVersionBySHA(test.sha)
+			if test.expected == "" {
+				assert.Nil(t, v)
+			} else {
+				require.NotNil(t, v)
+				assert.Equal(t, test.expected, v.Name)
+			}
+		})
+	}
+
+	t.Run("all", func(t *testing.T) {
+		for _, v := range goversions {
+			assert.Equal(t, v, ResolveGoVersionBySHA(v.SHA))
+		}
+	})
+}
+
+func TestResolvingDevelVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected *GoVersion
+	}{
+		{
+			"with version",
+			"devel go1.22-8f3f22e3a4 Tue Oct 3 16:12:57 2023 +0200",
+			&GoVersion{Name: "go1.22", SHA: "8f3f22e3a4", Timestamp: "2023-10-03T14:12:57Z"},
+		},
+		{
+			"with experiments",
+			"devel go1.21-8f3f22e3a4 Tue Oct 3 16:12:57 2023 +0000 X:loopvar",
+			&GoVersion{Name: "go1.21", SHA: "8f3f22e3a4", Timestamp: "2023-10-03T16:12:57Z"},
+		},
+		{
+			"without date",
+			"devel go1.22-8f3f22e3a4",
+			&GoVersion{Name: "go1.22", SHA: "8f3f22e3a4"},
+		},
+		{
+			"release commit",
+			"devel +205f850cea Mon May 13 20:03:09 2013 +0000",
+			goversions["go1.1"],
+		},
+		{"old format", "devel +8f3f22e3a4 Tue Oct 3 16:12:57 2017 +0000", nil},
+		{"invalid version", "devel go1.x-8f3f22e3a4 Tue Oct 3 16:12:57 2023 +0000", nil},
+		{"not devel", "go1.22-8f3f22e3a4", nil},
+		{"only devel", "devel", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ResolveGoVersion(test.tag))
+		})
+	}
+}
+
+func TestDevelVersionFromLayout(t *testing.T) {
+	const oldTag = "devel +8f3f22e3a4 Tue Oct 3 16:12:57 2017 +0000"
+
+	newFile := func(magic uint32) *GoFile {
+		f := newTestGoFile(nil, &FileInfo{ByteOrder: binary.LittleEndian})
+		f.pclntabBytes = binary.LittleEndian.AppendUint32(nil, magic)
+		return f
+	}
+
+	tests := []struct {
+		name     string
+		magic    uint32
+		tag      string
+		expected *GoVersion
+	}{
+		{"go1.16 table", gopclntab116magic, oldTag, &GoVersion{Name: "go1.16", SHA: "8f3f22e3a4", Timestamp: "2017-10-03T16:12:57Z"}},
+		{"go1.18 table", gopclntab118magic, oldTag, &GoVersion{Name: "go1.18", SHA: "8f3f22e3a4", Timestamp: "2017-10-03T16:12:57Z"}},
+		{"unknown table", 0xffffffff, oldTag, nil},
+		{"new format", gopclntab116magic, "devel go1.22-8f3f22e3a4 Tue Oct 3 16:12:57 2023 +0000", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, develVersionFromLayout(newFile(test.magic), test.tag))
+		})
+	}
+}
+
+func TestDevelVersionFromFuncs(t *testing.T) {
+	funcs := func(names ...string) []gosym.Func {
+		var fns []gosym.Func
+		for _, n := range names {
+			fns = append(fns, gosym.Func{Sym: &gosym.Sym{Name: n}})
+		}
+		return fns
+	}
+
+	tests := []struct {
+		name     string
+		fns      []gosym.Func
+		expected string
+	}{
+		{"go1.14", funcs("runtime.typelinksinit", "internal/bytealg.IndexByteString", "runtime.asyncPreempt"), "go1.14"},
+		{"go1.11", funcs("runtime.typelinksinit", "internal/bytealg.IndexByteString"), "go1.11"},
+		{"go1.7", funcs("runtime.main", "runtime.typelinksinit"), "go1.7"},
+		{"unknown", funcs("runtime.main"), ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, develVersionFromFuncs(test.fns))
+		})
+	}
+}
+
+func TestMatchDevelVersion(t *testing.T) {
+	data := []byte("garbage\x00devel go1.22-8f3f22e3a4 Tue Oct 3 16:12:57 2023 +0000\x00go1.21.0")
+	assert.Equal(t, "devel go1.22-8f3f22e3a4 Tue Oct 3 16:12:57 2023 +0000", string(develVersionMatcher.Find(data)))
+
+	data = []byte("devel +8f3f22e3a4 Tue Oct 13 16:12:57 2017 -0400 ")
+	assert.Equal(t, "devel +8f3f22e3a4 Tue Oct 13 16:12:57 2017 -0400", string(develVersionMatcher.Find(data)))
+
+	assert.Nil(t, develVersionMatcher.Find([]byte("devel go1.22 is not a version")))
+}
+
 func TestMatchGoVersion(t *testing.T) {
 	assert := assert.New(t)
 	padding := "teststringPadding"
@@ -92,6 +233,15 @@ func TestVersionComparer(t *testing.T) {
 		{"go1.7rc1", "go1.7", -1},
 		{"go1", "go1.4beta1", -1},
 		{"go1.4beta1", "go1", 1},
+		{"go1.20", "go1.20.0", 0},
+		{"go1.21", "go1.21.0", 0},
+		{"go1.21.0", "go1.21", 0},
+		{"go1.21.0", "go1.21.1", -1},
+		{"go1.21.1", "go1.21", 1},
+		{"go1.21rc1", "go1.21.0", -1},
+		{"go1.21rc2", "go1.21", -1},
+		{"go1.21", "go1.21rc2", 1},
+		{"go1.22rc1", "go1.21.5", 1},
 	}
 
 	for i, test := range tests {
@@ -101,6 +251,76 @@ func TestVersionComparer(t *testing.T) {
 	}
 }
 
+func TestGoVersionFromSymbol(t *testing.T) {
+	r := require.New(t)
+	fh := newStringSymbolsHandler(map[string]string{"runtime.buildVersion": "go1.22.8"})
+	fh.mGetDwarf = func() (*dwarf.Data, error) {
+		return nil, errors.New("no DWARF data")
+	}
+	f := newTestGoFile(fh, &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64})
+
+	ver, src, err := findGoCompilerVersion(f)
+	r.NoError(err)
+	r.Equal("go1.22.8", ver.Name)
+	r.Equal(VersionSourceSymbol, src)
+}
+
+func TestVersionConsistencyNormalized(t *testing.T) {
+	tests := []struct {
+		name       string
+		buildinfo  string
+		symbol     string
+		consistent bool
+		biVer      string
+		scanVer    string
+	}{
+		{"same", "go1.22.8", "go1.22.8", true, "go1.22.8", "go1.22.8"},
+		{"experiment", "go1.21.0 X:loopvar", "go1.21.0", true, "go1.21.0", "go1.21.0"},
+		{"patch level", "go1.21", "go1.21.0", true, "go1.21", "go1.21.0"},
+		{"tampered", "go1.19.9", "go1.22.8", false, "go1.19.9", "go1.22.8"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			fh := newStringSymbolsHandler(map[string]string{"runtime.buildVersion": test.symbol})
+			fh.mGetDwarf = func() (*dwarf.Data, error) {
+				return nil, errors.New("no DWARF data")
+			}
+			// The buildinfo structure in the inline format.
+			section := append([]byte("\xff Go buildinf:"), intSize64, buildInfoFlagsInline)
+			section = append(section, make([]byte, buildInfoHeaderSize-len(section))...)
+			section = append(binary.AppendUvarint(section, uint64(len(test.buildinfo))), test.buildinfo...)
+			section = binary.AppendUvarint(section, 0)
+			fh.mGetSectionData = func(name string) (uint64, []byte, error) {
+				if name == ".go.buildinfo" {
+					return 0x2000, section, nil
+				}
+				return 0, nil, ErrSectionDoesNotExist
+			}
+			f := newTestGoFile(fh, &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64})
+			// BuildInfo can be read from the symbols, it's not used for the
+			// comparison.
+			f.BuildInfo = &BuildInfo{ModInfo: &debug.BuildInfo{GoVersion: test.symbol}}
+
+			consistent, biVer, scanVer, err := f.VersionConsistency()
+			r.NoError(err)
+			r.Equal(test.consistent, consistent)
+			r.Equal(test.biVer, biVer)
+			r.Equal(test.scanVer, scanVer)
+		})
+	}
+
+	t.Run("no buildinfo", func(t *testing.T) {
+		fh := newStringSymbolsHandler(map[string]string{"runtime.buildVersion": "go1.22.8"})
+		fh.mGetSectionData = func(string) (uint64, []byte, error) {
+			return 0, nil, ErrSectionDoesNotExist
+		}
+		f := newTestGoFile(fh, &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64})
+		_, _, _, err := f.VersionConsistency()
+		require.ErrorIs(t, err, ErrNoBuildInfo)
+	})
+}
+
 func TestExtractVersionFromInitSched(t *testing.T) {
 	r := require.New(t)
 
@@ -23,6 +23,7 @@ import (
 	"compress/zlib"
 	"debug/dwarf"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"slices"
@@ -40,6 +41,7 @@ func openMachO(r io.ReaderAt) (*machoFile, error) {
 	}
 	ret := &machoFile{file: f, reader: r}
 	ret.getsymtab = sync.OnceValue(ret.initSymtab)
+	ret.getdwarf = sync.OnceValues(ret.initDwarf)
 	return ret, nil
 }
 
@@ -49,6 +51,8 @@ type machoFile struct {
 	file      *macho.File
 	reader    io.ReaderAt
 	getsymtab func() map[string]Symbol
+	getdwarf  func() (*dwarf.Data, error)
+	closer    closeGuard
 }
 
 func (m *machoFile) initSymtab() map[string]Symbol {
@@ -93,6 +97,10 @@ func (m *machoFile) getSymbol(name string) (Symbol, error) {
 	return sym, nil
 }
 
+func (m *machoFile) hasSymbolTable() bool {
+	return len(m.getsymtab()) > 0
+}
+
 func (m *machoFile) getParsedFile() any {
 	return m.file
 }
@@ -102,11 +110,13 @@ func (m *machoFile) getReader() io.ReaderAt {
 }
 
 func (m *machoFile) Close() error {
-	err := m.file.Close()
-	if err != nil {
-		return err
-	}
-	return tryClose(m.reader)
+	return m.closer.close(func() error {
+		err := m.file.Close()
+		if err != nil {
+			return err
+		}
+		return tryClose(m.reader)
+	})
 }
 
 func (m *machoFile) getRData() ([]byte, error) {
@@ -133,6 +143,75 @@ func (m *machoFile) getSectionDataFromAddress(address uint64) (uint64, []byte, e
 	return 0, nil, ErrSectionDoesNotExist
 }
 
+func (m *machoFile) getSectionNameFromAddress(address uint64) (string, error) {
+	for _, section := range m.file.Sections {
+		if section.Offset == 0 {
+			// Only exist in memory
+			continue
+		}
+
+		if section.Addr <= address && address < (section.Addr+section.Size) {
+			return section.Name, nil
+		}
+	}
+	return "", ErrSectionDoesNotExist
+}
+
+func (m *machoFile) isExecutableAddress(address uint64) bool {
+	for _, section := range m.file.Sections {
+		if !section.Flags.IsPureInstructions() && !section.Flags.IsSomeInstructions() {
+			continue
+		}
+
+		if section.Addr <= address && address < (section.Addr+section.Size) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *machoFile) getEntryPoint() (uint64, error) {
+	for _, l := range m.file.Loads {
+		switch l := l.(type) {
+		case *macho.EntryPoint:
+			// The entry is an offset from the start of the __TEXT segment.
+			text := m.file.Segment("__TEXT")
+			if text == nil {
+				return 0, ErrNoEntryPoint
+			}
+			return text.Addr + l.EntryOffset, nil
+		case *macho.UnixThread:
+			// The entry is the program counter in the initial thread state.
+			if len(l.Threads) == 0 {
+				continue
+			}
+			data := l.Threads[0].Data
+			var off, size int
+			switch m.file.CPU {
+			case types.CPUAmd64:
+				// After rax-r15.
+				off, size = 16*8, 8
+			case types.CPUArm64:
+				// After x0-x28, fp, lr and sp.
+				off, size = 32*8, 8
+			case types.CPUI386:
+				// After eax, ebx, ecx, edx, edi, esi, ebp, esp, ss and eflags.
+				off, size = 10*4, 4
+			default:
+				return 0, ErrNoEntryPoint
+			}
+			if len(data) < off+size {
+				return 0, ErrNoEntryPoint
+			}
+			if size == 4 {
+				return uint64(m.file.ByteOrder.Uint32(data[off:])), nil
+			}
+			return m.file.ByteOrder.Uint64(data[off:]), nil
+		}
+	}
+	return 0, ErrNoEntryPoint
+}
+
 func (m *machoFile) getSectionData(s string) (uint64, []byte, error) {
 	var section *types.Section
 	for _, sect := range m.file.Sections {
@@ -170,7 +249,68 @@ func (m *machoFile) getFileInfo() *FileInfo {
 }
 
 func (m *machoFile) getPCLNTABData() (uint64, []byte, error) {
-	return m.getSectionData("__gopclntab")
+	// The internal linker has always put the table in the "__TEXT,__gopclntab"
+	// section, including the oldest releases. Those also have a "__gosymtab"
+	// section with the old symbol table, but it's not needed since the function
+	// names are read from the PCLN table.
+	start, data, err := m.getSectionData("__gopclntab")
+	if err == nil {
+		return start, data, nil
+	}
+	if !errors.Is(err, ErrSectionDoesNotExist) {
+		return 0, nil, fmt.Errorf("accessing section data for __gopclntab failed: %w", err)
+	}
+
+	// For files that have been linked with an external linker, the table may not be in its
+	// own section. Instead, it's stored in one of the sections holding read-only or
+	// initialized data, so we have to search for it. The section names are used in
+	// multiple segments so all the sections with the name are checked.
+	for _, s := range m.file.Sections {
+		if s.Offset == 0 || (s.Name != "__const" && s.Name != "__data") {
+			continue
+		}
+		data, err := s.Data()
+		if err != nil {
+			continue
+		}
+
+		buf, err := searchSectionForTab(data, m.file.ByteOrder, false)
+		if err != nil {
+			continue
+		}
+
+		// The table is the "tail" of the section data, so the difference between the size
+		// of the section and the tail is the offset where the table starts.
+		vaddr := s.Addr + uint64(len(data)) - uint64(len(buf))
+
+		// The magic and the header can match by chance so ensure the rest of the header is
+		// valid too.
+		if _, err := newPCLNTable(buf, vaddr, 0, m.file.ByteOrder); err != nil {
+			continue
+		}
+
+		return vaddr, buf, nil
+	}
+
+	return 0, nil, fmt.Errorf("error when search for pclntab: %w", ErrNoPCLNTab)
+}
+
+func (m *machoFile) getPCLNTABCandidates() []uint64 {
+	var addrs []uint64
+	if start, _, err := m.getSectionData("__gopclntab"); err == nil {
+		addrs = append(addrs, start)
+	}
+	for _, s := range m.file.Sections {
+		if s.Offset == 0 || (s.Name != "__const" && s.Name != "__data") {
+			continue
+		}
+		data, err := s.Data()
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, pclntabCandidates(s.Addr, data, m.file.ByteOrder, false)...)
+	}
+	return addrs
 }
 
 func (m *machoFile) moduledataSection() string {
@@ -185,19 +325,54 @@ func (m *machoFile) getBuildID() (string, error) {
 	return parseBuildIDFromRaw(data)
 }
 
+// machoSectionNameLen is the size of the section name field in a Mach-O
+// section header.
+const machoSectionNameLen = 16
+
+// machoDwarfSections are the DWARF sections handled by the debug/dwarf package.
+var machoDwarfSections = []string{
+	"abbrev", "addr", "info", "line", "line_str", "loclists",
+	"ranges", "rnglists", "str", "str_offsets", "types",
+}
+
+// machoDwarfSuffix returns the DWARF section name without the "__debug_" or
+// "__zdebug_" prefix. An empty string is returned if it's not a DWARF section.
+// Mach-O section names are limited to 16 characters, so longer names, for
+// example "__debug_str_offsets" in binaries linked by clang, are truncated.
+// Truncated names are expanded to the full DWARF section name.
+func machoDwarfSuffix(name string) string {
+	var suffix string
+	switch {
+	case strings.HasPrefix(name, "__debug_"):
+		suffix = name[8:]
+	case strings.HasPrefix(name, "__zdebug_"):
+		suffix = name[9:]
+	default:
+		return ""
+	}
+	if len(name) < machoSectionNameLen {
+		return suffix
+	}
+	for _, s := range machoDwarfSections {
+		if s == suffix {
+			return s
+		}
+	}
+	for _, s := range machoDwarfSections {
+		if strings.HasPrefix(s, suffix) {
+			return s
+		}
+	}
+	return suffix
+}
+
 // getDwarf mostly a copy of github.com/blacktop/go-macho.File.DWARF() function
 // removes dependency on github.com/blacktop/go-dwarf package
 func (m *machoFile) getDwarf() (*dwarf.Data, error) {
-	dwarfSuffix := func(s *types.Section) string {
-		switch {
-		case strings.HasPrefix(s.Name, "__debug_"):
-			return s.Name[8:]
-		case strings.HasPrefix(s.Name, "__zdebug_"):
-			return s.Name[9:]
-		default:
-			return ""
-		}
-	}
+	return m.getdwarf()
+}
+
+func (m *machoFile) initDwarf() (*dwarf.Data, error) {
 	sectionData := func(s *types.Section) ([]byte, error) {
 		b, err := s.Data()
 		if err != nil && uint64(len(b)) < s.Size {
@@ -227,7 +402,7 @@ func (m *machoFile) getDwarf() (*dwarf.Data, error) {
 	// Don't bother loading others.
 	var dat = map[string][]byte{"abbrev": nil, "info": nil, "str": nil, "line": nil, "ranges": nil}
 	for _, s := range m.file.Sections {
-		suffix := dwarfSuffix(s)
+		suffix := machoDwarfSuffix(s.Name)
 		if suffix == "" {
 			continue
 		}
@@ -248,7 +423,7 @@ func (m *machoFile) getDwarf() (*dwarf.Data, error) {
 
 	// Look for DWARF4 .debug_types sections and DWARF5 sections.
 	for i, s := range m.file.Sections {
-		suffix := dwarfSuffix(s)
+		suffix := machoDwarfSuffix(s.Name)
 		if suffix == "" {
 			continue
 		}
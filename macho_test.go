@@ -0,0 +1,140 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"debug/dwarf"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blacktop/go-macho"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMachODwarfSuffix(t *testing.T) {
+	tests := []struct {
+		section string
+		want    string
+	}{
+		{"__debug_info", "info"},
+		{"__debug_abbrev", "abbrev"},
+		{"__zdebug_info", "info"},
+		{"__zdebug_abbrev", "abbrev"},
+		{"__debug_line_str", "line_str"},
+		{"__debug_str_offs", "str_offsets"},
+		{"__debug_rnglists", "rnglists"},
+		{"__zdebug_str_off", "str_offsets"},
+		{"__zdebug_line_st", "line_str"},
+		{"__zdebug_rnglist", "rnglists"},
+		{"__debug_pubnames", "pubnames"},
+		{"__apple_names", ""},
+		{"__text", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.section, func(t *testing.T) {
+			assert.Equal(t, test.want, machoDwarfSuffix(test.section))
+		})
+	}
+}
+
+func TestMachOExternalLinkerDwarf(t *testing.T) {
+	// The binary can only be built on macOS, see testdata/build.go.
+	testFile := filepath.Join("testdata", "gold", "darwin-ext")
+	if _, err := os.Stat(testFile); err != nil {
+		t.Skip("No externally linked macOS binary")
+	}
+	r := require.New(t)
+
+	f, err := Open(testFile)
+	r.NoError(err)
+	defer f.Close()
+
+	// All the DWARF sections in the "__DWARF" segment should be recognized.
+	for _, s := range f.GetParsedFile().(*macho.File).Sections {
+		if s.Seg != "__DWARF" || !strings.HasPrefix(s.Name, "__debug_") && !strings.HasPrefix(s.Name, "__zdebug_") {
+			continue
+		}
+		assert.NotEmpty(t, machoDwarfSuffix(s.Name), "section %s not recognized", s.Name)
+	}
+
+	d, err := f.fh.getDwarf()
+	r.NoError(err)
+
+	// The compile units and their line tables should be readable.
+	var hasMain bool
+	reader := d.Reader()
+	for {
+		cu, err := reader.Next()
+		r.NoError(err)
+		if cu == nil {
+			break
+		}
+		reader.SkipChildren()
+		if name, _ := cu.Val(dwarf.AttrName).(string); name == "main" {
+			hasMain = true
+			lr, err := d.LineReader(cu)
+			r.NoError(err)
+			r.NotNil(lr)
+		}
+	}
+	r.True(hasMain, "the main package's compile unit should be found")
+
+	ver, err := f.GetCompilerVersion()
+	r.NoError(err)
+	r.NotNil(ver)
+}
+
+func TestMachOGoldPCLNTab(t *testing.T) {
+	goldFiles, err := getGoldenResources()
+	if err != nil || len(goldFiles) == 0 {
+		t.Skip("No golden files")
+	}
+
+	// The oldest darwin builds must find the table in the same section as
+	// the new ones.
+	var tested bool
+	for _, file := range goldFiles {
+		if !strings.HasPrefix(file, "gold-darwin-") || !strings.HasSuffix(file, "-1.5.0") {
+			continue
+		}
+		tested = true
+		t.Run(file, func(t *testing.T) {
+			r := require.New(t)
+			resource, err := getGoldTestResourcePath(file)
+			r.NoError(err)
+			f, err := Open(resource)
+			r.NoError(err)
+			defer f.Close()
+
+			section, err := f.PCLNTabSection()
+			r.NoError(err)
+			r.Equal("__gopclntab", section)
+
+			tab, err := f.PCLNTab()
+			r.NoError(err)
+			r.NotNil(tab.LookupFunc("main.main"))
+		})
+	}
+	if !tested {
+		t.Skip("No Go 1.5 darwin golden files")
+	}
+}
@@ -18,10 +18,17 @@
 package gore
 
 import (
+	"bytes"
 	"debug/buildinfo"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/goretk/gore/extern"
+	"github.com/goretk/gore/extern/gover"
 )
 
 var (
@@ -38,16 +45,492 @@ type BuildInfo struct {
 	ModInfo *debug.BuildInfo
 }
 
-func (f *GoFile) extractBuildInfo() (*BuildInfo, error) {
+// BuildInfoRaw holds the undecoded buildinfo structure.
+type BuildInfoRaw struct {
+	// Address is the virtual address of the structure.
+	Address uint64
+	// Data is the structure as it is stored in the file. Since Go 1.18, it's
+	// the 32 byte header followed by the version and module information
+	// strings, each prefixed with its length as a uvarint. For older
+	// binaries, it's only the header which holds pointers to the strings.
+	Data []byte
+	// Version is the compiler version string.
+	Version []byte
+	// ModInfo is the module information string, including its start and
+	// end markers.
+	ModInfo []byte
+}
+
+// Module holds information about a Go module used to build the binary.
+type Module struct {
+	// Path is the module's path.
+	Path string
+	// Version is the module's version.
+	Version string
+	// Sum is the checksum of the module.
+	Sum string
+	// Replace is the module that replaced this module by a replace
+	// directive. It is nil if the module wasn't replaced.
+	Replace *Module
+}
+
+// Dependencies returns the modules the binary depends on, as recorded in
+// the build information. If the binary has no module information,
+// ErrNoBuildInfo is returned.
+func (f *GoFile) Dependencies() ([]Module, error) {
+	if f.BuildInfo == nil || f.BuildInfo.ModInfo == nil {
+		return nil, ErrNoBuildInfo
+	}
+
+	deps := make([]Module, 0, len(f.BuildInfo.ModInfo.Deps))
+	for _, d := range f.BuildInfo.ModInfo.Deps {
+		deps = append(deps, newModule(d))
+	}
+	return deps, nil
+}
+
+// PackagesByModule returns the main and vendor packages grouped by the path of
+// the module they belong to. A package belongs to the module with the longest
+// path that is equal to the package's import path or is a prefix of it, so
+// packages of nested modules are grouped under the nested module. The "main"
+// package belongs to the main module. Packages that don't belong to any of the
+// modules in the build information are grouped under an empty string. If the
+// binary has no module information, ErrNoBuildInfo is returned.
+func (f *GoFile) PackagesByModule() (map[string][]*Package, error) {
+	if f.BuildInfo == nil || f.BuildInfo.ModInfo == nil {
+		return nil, ErrNoBuildInfo
+	}
+	info := f.BuildInfo.ModInfo
+
+	pkgs, err := f.GetPackages()
+	if err != nil {
+		return nil, err
+	}
+	vendors, err := f.GetVendors()
+	if err != nil {
+		return nil, err
+	}
+
+	mods := make([]string, 0, len(info.Deps)+1)
+	if info.Main.Path != "" {
+		mods = append(mods, info.Main.Path)
+	}
+	for _, d := range info.Deps {
+		mods = append(mods, d.Path)
+	}
+
+	byModule := make(map[string][]*Package)
+	for _, p := range append(append([]*Package{}, pkgs...), vendors...) {
+		mod := info.Main.Path
+		if p.Name != "main" {
+			mod = packageModule(p.Name, mods)
+		}
+		byModule[mod] = append(byModule[mod], p)
+	}
+	return byModule, nil
+}
+
+// packageModule returns the longest module path that the import path is part
+// of, or an empty string if it isn't part of any of the modules.
+func packageModule(importPath string, mods []string) string {
+	var match string
+	for _, m := range mods {
+		if len(m) <= len(match) {
+			continue
+		}
+		if importPath == m || strings.HasPrefix(importPath, m+"/") {
+			match = m
+		}
+	}
+	return match
+}
+
+// newModule converts the module information from the runtime/debug package.
+func newModule(m *debug.Module) Module {
+	mod := Module{
+		Path:    m.Path,
+		Version: m.Version,
+		Sum:     m.Sum,
+	}
+	if m.Replace != nil {
+		r := newModule(m.Replace)
+		mod.Replace = &r
+	}
+	return mod
+}
+
+// BuildSetting is a key-value pair describing one setting that influenced
+// the build, for example "GOOS" or "-ldflags".
+type BuildSetting struct {
+	// Key is the name of the setting.
+	Key string
+	// Value is the value of the setting.
+	Value string
+}
+
+// BuildSettings returns the build settings recorded in the build information,
+// in the order they are stored in the binary. Binaries compiled with Go 1.18
+// and later have the settings. Since Go 1.21, the default GODEBUG settings
+// are recorded under the "DefaultGODEBUG" key, see also DefaultGODEBUG. The
+// version of the toolchain that compiled the binary, which may differ from
+// the go directive of the main module, is available from GetCompilerVersion.
+// If the binary has no module information, ErrNoBuildInfo is returned.
+func (f *GoFile) BuildSettings() ([]BuildSetting, error) {
+	if f.BuildInfo == nil || f.BuildInfo.ModInfo == nil {
+		return nil, ErrNoBuildInfo
+	}
+
+	settings := make([]BuildSetting, 0, len(f.BuildInfo.ModInfo.Settings))
+	for _, s := range f.BuildInfo.ModInfo.Settings {
+		settings = append(settings, BuildSetting{Key: s.Key, Value: s.Value})
+	}
+	return settings, nil
+}
+
+// DefaultGODEBUG returns the default GODEBUG settings baked into the binary,
+// for example "http2server" mapped to "0". The defaults are derived from the
+// go directive and the godebug directives of the main module, and change the
+// behavior of the runtime and the standard library. The map is empty if the
+// binary has no defaults, which is always the case for binaries compiled with
+// Go versions before 1.21. The go directive itself is not recorded in the
+// build information, but it can be derived from the defaults, see
+// ModuleGoVersion. If the binary has no module information, ErrNoBuildInfo
+// is returned.
+func (f *GoFile) DefaultGODEBUG() (map[string]string, error) {
+	settings, err := f.BuildSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	godebug := make(map[string]string)
+	for _, s := range settings {
+		if s.Key != "DefaultGODEBUG" {
+			continue
+		}
+		for _, kv := range strings.Split(s.Value, ",") {
+			k, v, ok := strings.Cut(strings.TrimSpace(kv), "=")
+			if !ok || k == "" {
+				continue
+			}
+			godebug[k] = v
+		}
+	}
+	return godebug, nil
+}
+
+// godebugChange is a GODEBUG setting whose default changed in a Go release.
+type godebugChange struct {
+	// minor is the minor version of the release, 21 means Go 1.21.
+	minor int
+	// old is the value that restores the behavior before the release.
+	old string
+}
+
+// godebugChanges holds the GODEBUG settings whose default changed since Go
+// 1.21, including the ones that have been removed since, keyed by their name.
+var godebugChanges = map[string]godebugChange{
+	"panicnil":                    {21, "1"},
+	"httplaxcontentlength":        {22, "1"},
+	"httpmuxgo121":                {22, "1"},
+	"tls10server":                 {22, "1"},
+	"tlsrsakex":                   {22, "1"},
+	"tlsunsafeekm":                {22, "1"},
+	"asynctimerchan":              {23, "1"},
+	"gotypesalias":                {23, "0"},
+	"httpservecontentkeepheaders": {23, "1"},
+	"tls3des":                     {23, "1"},
+	"tlskyber":                    {23, "0"},
+	"winreadlinkvolume":           {23, "0"},
+	"winsymlink":                  {23, "0"},
+	"x509keypairleaf":             {23, "0"},
+	"x509negativeserial":          {23, "1"},
+	"gotestjsonbuildtext":         {24, "1"},
+	"httpcookiemaxnum":            {24, "0"},
+	"multipathtcp":                {24, "0"},
+	"randseednop":                 {24, "0"},
+	"rsa1024min":                  {24, "0"},
+	"tlsmlkem":                    {24, "0"},
+	"urlmaxqueryparams":           {24, "0"},
+	"x509rsacrt":                  {24, "0"},
+	"x509usepolicies":             {24, "0"},
+	"containermaxprocs":           {25, "0"},
+	"decoratemappings":            {25, "0"},
+	"tlssha1":                     {25, "1"},
+	"updatemaxprocs":              {25, "0"},
+	"x509sha256skid":              {25, "0"},
+	"cryptocustomrand":            {26, "1"},
+	"tlssecpmlkem":                {26, "0"},
+	"urlstrictcolons":             {26, "0"},
+	"tracebacklabels":             {27, "0"},
+	"x509sslcertoverrideplatform": {27, "0"},
+}
+
+// ModuleGoVersion returns the Go version declared by the go directive of the
+// main module, for example "go1.21". It can be older than the version of the
+// toolchain that compiled the binary. The directive is not recorded in the
+// build information, so it's derived from the default GODEBUG settings. The
+// toolchain sets every setting whose default changed after the declared
+// version to its old value, so the version is the one before the oldest
+// change found, or the toolchain's version if there is none. Since the go
+// directives older than Go 1.20 get the defaults of Go 1.20, "go1.20" is
+// returned for them too. A godebug directive that restores an old value makes
+// the version look older than declared. The defaults were added in Go 1.21,
+// so ErrUnsupportedGoVersion is returned for binaries compiled with older
+// versions. If the binary has no main module, ErrNoBuildInfo is returned.
+func (f *GoFile) ModuleGoVersion() (string, error) {
+	if f.BuildInfo == nil || f.BuildInfo.ModInfo == nil || f.BuildInfo.ModInfo.Main.Path == "" {
+		return "", ErrNoBuildInfo
+	}
+	v := gover.Parse(extern.StripGo(versionField(f.BuildInfo.ModInfo.GoVersion)))
+	minor, err := strconv.Atoi(v.Minor)
+	if v.Major != "1" || err != nil {
+		return "", ErrInvalidGoVersion
+	}
+	if minor < 21 {
+		return "", ErrUnsupportedGoVersion
+	}
+
+	godebug, err := f.DefaultGODEBUG()
+	if err != nil {
+		return "", err
+	}
+	// Start from the toolchain's version since the go directive can't be
+	// newer.
+	for name, value := range godebug {
+		if c, ok := godebugChanges[name]; ok && value == c.old && c.minor <= minor {
+			minor = c.minor - 1
+		}
+	}
+	return fmt.Sprintf("go1.%d", minor), nil
+}
+
+// extractBuildInfo reads the buildinfo structure with the standard library's
+// debug/buildinfo package. It handles both the pointer based format used
+// before Go 1.18 and the inline varint-length format used since. If it fails,
+// the structure is read by BuildInfoRaw, which searches more sections for it.
+// If the structure can't be read, for example because its header has been
+// removed, the build information is read from the runtime.buildVersion and
+// runtime.modinfo variables if the binary has a symbol table. Otherwise, the
+// module information is searched for. The source of the compiler version is
+// returned with the build information.
+func (f *GoFile) extractBuildInfo() (*BuildInfo, string, error) {
 	info, err := buildinfo.Read(f.fh.getReader())
+	if err == nil {
+		return &BuildInfo{Compiler: ResolveGoVersion(info.GoVersion), ModInfo: info}, VersionSourceBuildInfo, nil
+	}
+
+	if info, rawErr := f.buildInfoFromRaw(); rawErr == nil {
+		return &BuildInfo{Compiler: ResolveGoVersion(info.GoVersion), ModInfo: info}, VersionSourceBuildInfo, nil
+	}
+
+	if info, symErr := f.buildInfoFromSymbols(); symErr == nil {
+		return &BuildInfo{Compiler: ResolveGoVersion(info.GoVersion), ModInfo: info}, VersionSourceSymbol, nil
+	}
+
+	// The module information doesn't hold the compiler version.
+	info, scanErr := f.scanModInfo()
+	if scanErr != nil {
+		return nil, "", fmt.Errorf("error when extracting build information: %w", err)
+	}
+	return &BuildInfo{ModInfo: info}, "", nil
+}
+
+var (
+	// modInfoStart and modInfoEnd are the markers the go command wraps the
+	// module information in.
+	modInfoStart = []byte("0w\xaf\x0c\x92t\x08\x02A\xe1\xc1\x07\xe6\xd6\x18\xe6")
+	modInfoEnd   = []byte("\xf92C1\x86\x18 r\x00\x82B\x10A\x16\xd8\xf2")
+)
+
+// buildInfoFromRaw parses the buildinfo structure returned by BuildInfoRaw.
+// Like the standard library, an empty module information string is accepted
+// since the binaries built outside of a module don't have one.
+func (f *GoFile) buildInfoFromRaw() (*debug.BuildInfo, error) {
+	raw, err := f.BuildInfoRaw()
+	if err != nil {
+		return nil, err
+	}
+	info := &debug.BuildInfo{}
+	if len(raw.ModInfo) != 0 {
+		info, err = parseModInfo(string(raw.ModInfo))
+		if err != nil {
+			return nil, fmt.Errorf("buildinfo at 0x%x: %w", raw.Address, err)
+		}
+	}
+	info.GoVersion = string(raw.Version)
+	return info, nil
+}
+
+// parseModInfo parses the module information string wrapped in the markers.
+func parseModInfo(modinfo string) (*debug.BuildInfo, error) {
+	if len(modinfo) < len(modInfoStart)+len(modInfoEnd) ||
+		!strings.HasPrefix(modinfo, string(modInfoStart)) || !strings.HasSuffix(modinfo, string(modInfoEnd)) {
+		return nil, errors.New("the module information is not wrapped in the markers")
+	}
+	return debug.ParseBuildInfo(modinfo[len(modInfoStart) : len(modinfo)-len(modInfoEnd)])
+}
+
+// buildInfoFromSymbols reads the build information from the runtime.modinfo
+// and runtime.buildVersion variables located by the symbol table. The module
+// information string is wrapped in the markers, like in the buildinfo
+// structure.
+func (f *GoFile) buildInfoFromSymbols() (*debug.BuildInfo, error) {
+	modinfo, err := f.readStringSymbol("runtime.modinfo")
+	if err != nil {
+		return nil, err
+	}
+	info, err := parseModInfo(modinfo)
 	if err != nil {
-		return nil, fmt.Errorf("error when extracting build information: %w", err)
+		return nil, fmt.Errorf("runtime.modinfo: %w", err)
+	}
+	version, err := f.readStringSymbol("runtime.buildVersion")
+	if err != nil {
+		return nil, err
+	}
+	info.GoVersion = version
+	return info, nil
+}
+
+// scanModInfo searches the read-only data and the sections that can hold the
+// buildinfo structure for the module information string. The string is stored
+// in the read-only data before Go 1.18 and after the buildinfo header since,
+// so it can be found even if the header has been removed or zeroed. The
+// compiler version is not part of the string, so it's not set in the result.
+func (f *GoFile) scanModInfo() (*debug.BuildInfo, error) {
+	for _, name := range append(rodataSections, buildInfoSections...) {
+		_, data, err := f.fh.getSectionData(name)
+		if err != nil {
+			continue
+		}
+		for {
+			start := bytes.Index(data, modInfoStart)
+			if start == -1 {
+				break
+			}
+			data = data[start+len(modInfoStart):]
+			end := bytes.Index(data, modInfoEnd)
+			if end == -1 {
+				break
+			}
+			// The markers can match by chance, so the string has to hold at
+			// least the path of the main package.
+			if info, err := debug.ParseBuildInfo(string(data[:end])); err == nil && info.Path != "" {
+				return info, nil
+			}
+		}
+	}
+	return nil, ErrNoBuildInfo
+}
+
+const (
+	// buildInfoHeaderSize is the size of the buildinfo header.
+	buildInfoHeaderSize = 32
+	// buildInfoAlign is the alignment of the buildinfo header.
+	buildInfoAlign = 16
+	// buildInfoFlagsEndian is set in the header's flags if the pointers are
+	// big endian.
+	buildInfoFlagsEndian = 0x1
+	// buildInfoFlagsInline is set in the header's flags if the strings
+	// follow the header, which is the case since Go 1.18.
+	buildInfoFlagsInline = 0x2
+)
+
+// buildInfoMagic is the start of the buildinfo header.
+var buildInfoMagic = []byte("\xff Go buildinf:")
+
+// buildInfoSections are the sections the buildinfo header is searched in.
+// The linker puts it in its own section for ELF and Mach-O files and at the
+// start of the data section for the other formats.
+var buildInfoSections = []string{".go.buildinfo", "__go_buildinfo", ".data", "__data", "data"}
+
+// BuildInfoRaw returns the undecoded buildinfo structure, so it can be
+// inspected when it fails to parse. The structure starts with a 32 byte header
+// holding the magic, the pointer size and flags. Since Go 1.18, the header is
+// followed by the compiler version and the module information strings. For
+// older binaries, the header holds pointers to the two strings, which are
+// dereferenced. If the structure can't be found, ErrNoBuildInfo is returned.
+func (f *GoFile) BuildInfoRaw() (*BuildInfoRaw, error) {
+	for _, name := range buildInfoSections {
+		addr, data, err := f.fh.getSectionData(name)
+		if err != nil {
+			continue
+		}
+		for off := 0; off+buildInfoHeaderSize <= len(data); off += buildInfoAlign {
+			if bytes.HasPrefix(data[off:], buildInfoMagic) {
+				return f.readBuildInfoRaw(addr+uint64(off), data[off:])
+			}
+		}
+	}
+	return nil, ErrNoBuildInfo
+}
+
+// readBuildInfoRaw returns the buildinfo structure starting at the beginning
+// of the data, which is located at the address.
+func (f *GoFile) readBuildInfoRaw(addr uint64, data []byte) (*BuildInfoRaw, error) {
+	flags := data[len(buildInfoMagic)+1]
+	if flags&buildInfoFlagsInline != 0 {
+		var strs [2][]byte
+		end := buildInfoHeaderSize
+		for i := range strs {
+			n, l := binary.Uvarint(data[end:])
+			if l <= 0 || n > uint64(len(data)-end-l) {
+				return nil, fmt.Errorf("buildinfo at 0x%x is truncated: %w", addr, ErrNoBuildInfo)
+			}
+			strs[i] = data[end+l : end+l+int(n)]
+			end += l + int(n)
+		}
+		return &BuildInfoRaw{
+			Address: addr,
+			Data:    bytes.Clone(data[:end]),
+			Version: bytes.Clone(strs[0]),
+			ModInfo: bytes.Clone(strs[1]),
+		}, nil
 	}
 
-	result := &BuildInfo{
-		Compiler: ResolveGoVersion(info.GoVersion),
-		ModInfo:  info,
+	ptrSize := int(data[len(buildInfoMagic)])
+	if ptrSize != intSize32 && ptrSize != intSize64 {
+		return nil, fmt.Errorf("buildinfo at 0x%x has an invalid pointer size %d: %w", addr, ptrSize, ErrNoBuildInfo)
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if flags&buildInfoFlagsEndian != 0 {
+		order = binary.BigEndian
+	}
+	readPtr := func(b []byte) (uint64, error) {
+		return readUIntTo64(bytes.NewReader(b), order, ptrSize == intSize32)
 	}
 
-	return result, nil
+	var strs [2][]byte
+	for i := range strs {
+		off := len(buildInfoMagic) + 2 + i*ptrSize
+		strAddr, err := readPtr(data[off:])
+		if err != nil {
+			return nil, err
+		}
+		hdr, err := f.Bytes(strAddr, uint64(2*ptrSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the string header at 0x%x: %w", strAddr, err)
+		}
+		ptr, err := readPtr(hdr)
+		if err != nil {
+			return nil, err
+		}
+		length, err := readPtr(hdr[ptrSize:])
+		if err != nil {
+			return nil, err
+		}
+		if length == 0 {
+			continue
+		}
+		str, err := f.Bytes(ptr, length)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the string at 0x%x: %w", ptr, err)
+		}
+		strs[i] = bytes.Clone(str)
+	}
+	return &BuildInfoRaw{
+		Address: addr,
+		Data:    bytes.Clone(data[:buildInfoHeaderSize]),
+		Version: strs[0],
+		ModInfo: strs[1],
+	}, nil
 }
@@ -18,7 +18,13 @@
 package gore
 
 import (
+	"bytes"
+	"encoding/binary"
+	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
 	"strings"
 	"testing"
 
@@ -86,3 +92,555 @@ func TestBuildInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestDependencies(t *testing.T) {
+	t.Run("no build info", func(t *testing.T) {
+		f := &GoFile{}
+		_, err := f.Dependencies()
+		require.ErrorIs(t, err, ErrNoBuildInfo)
+	})
+
+	t.Run("with replace", func(t *testing.T) {
+		f := &GoFile{BuildInfo: &BuildInfo{ModInfo: &debug.BuildInfo{Deps: []*debug.Module{
+			{Path: "github.com/a/a", Version: "v1.0.0", Sum: "h1:a"},
+			{Path: "github.com/b/b", Version: "v1.2.0", Replace: &debug.Module{Path: "../b", Version: "(devel)"}},
+		}}}}
+
+		deps, err := f.Dependencies()
+		require.NoError(t, err)
+		require.Equal(t, []Module{
+			{Path: "github.com/a/a", Version: "v1.0.0", Sum: "h1:a"},
+			{Path: "github.com/b/b", Version: "v1.2.0", Replace: &Module{Path: "../b", Version: "(devel)"}},
+		}, deps)
+	})
+}
+
+func TestPackagesByModule(t *testing.T) {
+	t.Run("no build info", func(t *testing.T) {
+		f := &GoFile{}
+		_, err := f.PackagesByModule()
+		require.ErrorIs(t, err, ErrNoBuildInfo)
+	})
+
+	t.Run("grouped", func(t *testing.T) {
+		r := require.New(t)
+		mainPkg := &Package{Name: "main"}
+		util := &Package{Name: "example.com/app/internal/util"}
+		cobra := &Package{Name: "github.com/spf13/cobra"}
+		pflag := &Package{Name: "github.com/spf13/pflag"}
+		gcloud := &Package{Name: "cloud.google.com/go/internal"}
+		storage := &Package{Name: "cloud.google.com/go/storage/internal/apiv2"}
+		// A module path that is a prefix of the package's name, but not of
+		// its path elements, is not a match.
+		other := &Package{Name: "github.com/spf13/cobra-cli"}
+
+		f := newTestGoFile(nil, nil, mainPkg, util)
+		f.vendors = []*Package{cobra, pflag, gcloud, storage, other}
+		f.BuildInfo = &BuildInfo{ModInfo: &debug.BuildInfo{
+			Main: debug.Module{Path: "example.com/app"},
+			Deps: []*debug.Module{
+				{Path: "github.com/spf13/cobra"},
+				{Path: "cloud.google.com/go"},
+				{Path: "cloud.google.com/go/storage"},
+				{Path: "github.com/spf13/pflag", Replace: &debug.Module{Path: "../pflag"}},
+			},
+		}}
+
+		mods, err := f.PackagesByModule()
+		r.NoError(err)
+		r.Equal(map[string][]*Package{
+			"example.com/app":             {mainPkg, util},
+			"github.com/spf13/cobra":      {cobra},
+			"github.com/spf13/pflag":      {pflag},
+			"cloud.google.com/go":         {gcloud},
+			"cloud.google.com/go/storage": {storage},
+			"":                            {other},
+		}, mods)
+	})
+}
+
+func TestBuildSettings(t *testing.T) {
+	t.Run("no build info", func(t *testing.T) {
+		f := &GoFile{}
+		_, err := f.BuildSettings()
+		require.ErrorIs(t, err, ErrNoBuildInfo)
+		_, err = f.DefaultGODEBUG()
+		require.ErrorIs(t, err, ErrNoBuildInfo)
+	})
+
+	t.Run("go1.21 settings", func(t *testing.T) {
+		r := require.New(t)
+		info, err := debug.ParseBuildInfo(strings.Join([]string{
+			"go\tgo1.22.8",
+			"path\tgithub.com/goretk/gore/gold",
+			"mod\tgithub.com/goretk/gore/gold\t(devel)\t",
+			"build\t-buildmode=exe",
+			"build\t-compiler=gc",
+			"build\t-ldflags=\"-s -w -X main.version=1.0\"",
+			"build\tDefaultGODEBUG=httplaxcontentlength=1,httpmuxgo121=1,panicnil=1,tls10server=1",
+			"build\tCGO_ENABLED=0",
+			"build\tGOARCH=amd64",
+			"build\tGOOS=linux",
+			"build\tGOAMD64=v1",
+			"",
+		}, "\n"))
+		r.NoError(err)
+		f := &GoFile{BuildInfo: &BuildInfo{ModInfo: info}}
+
+		settings, err := f.BuildSettings()
+		r.NoError(err)
+		r.Len(settings, 8)
+		r.Equal(BuildSetting{Key: "-ldflags", Value: "-s -w -X main.version=1.0"}, settings[2])
+		r.Equal("DefaultGODEBUG", settings[3].Key)
+		r.Equal(BuildSetting{Key: "GOAMD64", Value: "v1"}, settings[7])
+
+		godebug, err := f.DefaultGODEBUG()
+		r.NoError(err)
+		r.Equal(map[string]string{
+			"httplaxcontentlength": "1",
+			"httpmuxgo121":         "1",
+			"panicnil":             "1",
+			"tls10server":          "1",
+		}, godebug)
+	})
+
+	t.Run("no default godebug", func(t *testing.T) {
+		f := &GoFile{BuildInfo: &BuildInfo{ModInfo: &debug.BuildInfo{Settings: []debug.BuildSetting{
+			{Key: "GOOS", Value: "linux"},
+		}}}}
+		godebug, err := f.DefaultGODEBUG()
+		require.NoError(t, err)
+		require.Empty(t, godebug)
+	})
+}
+
+func TestModuleGoVersion(t *testing.T) {
+	newFile := func(goVersion, mainPath, godebug string) *GoFile {
+		info := &debug.BuildInfo{GoVersion: goVersion, Main: debug.Module{Path: mainPath}}
+		if godebug != "" {
+			info.Settings = []debug.BuildSetting{{Key: "DefaultGODEBUG", Value: godebug}}
+		}
+		return &GoFile{BuildInfo: &BuildInfo{ModInfo: info}}
+	}
+
+	tests := []struct {
+		name     string
+		f        *GoFile
+		expected string
+		err      error
+	}{
+		{"same as toolchain", newFile("go1.22.8", "example.com/app", ""), "go1.22", nil},
+		{"go1.21", newFile("go1.22.8", "example.com/app", "httplaxcontentlength=1,httpmuxgo121=1,tls10server=1"), "go1.21", nil},
+		{"go1.20", newFile("go1.22.8", "example.com/app", "httplaxcontentlength=1,httpmuxgo121=1,panicnil=1,tls10server=1"), "go1.20", nil},
+		{"removed setting", newFile("go1.23.2", "example.com/app", "asynctimerchan=1,gotypesalias=0"), "go1.22", nil},
+		{"new value", newFile("go1.22.8", "example.com/app", "httpmuxgo121=0,panicnil=0"), "go1.22", nil},
+		{"unknown setting", newFile("go1.22.8", "example.com/app", "http2client=0"), "go1.22", nil},
+		{"experiments", newFile("go1.22.8 X:loopvar", "example.com/app", "httpmuxgo121=1"), "go1.21", nil},
+		{"release candidate", newFile("go1.23rc1", "example.com/app", ""), "go1.23", nil},
+		{"old toolchain", newFile("go1.20.14", "example.com/app", ""), "", ErrUnsupportedGoVersion},
+		{"devel toolchain", newFile("devel go1.23-d7ee9cd Tue Jun 11 16:35:47 2024 +0000", "example.com/app", ""), "", ErrInvalidGoVersion},
+		{"no main module", newFile("go1.22.8", "", ""), "", ErrNoBuildInfo},
+		{"no build info", &GoFile{}, "", ErrNoBuildInfo},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v, err := test.f.ModuleGoVersion()
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, v)
+		})
+	}
+
+	t.Run("binary", func(t *testing.T) {
+		r := require.New(t)
+		goBin, err := exec.LookPath("go")
+		if err != nil {
+			t.Skip("No go tool chain found")
+		}
+		tmpdir := t.TempDir()
+		r.NoError(os.WriteFile(filepath.Join(tmpdir, "go.mod"), []byte("module example.com/app\n\ngo 1.22\n"), 0644))
+		r.NoError(os.WriteFile(filepath.Join(tmpdir, "main.go"), []byte(testresourcesrc), 0644))
+		exe := filepath.Join(tmpdir, "app")
+		cmd := exec.Command(goBin, "build", "-o", exe, ".")
+		cmd.Dir = tmpdir
+		cmd.Env = append(os.Environ(), "GOOS=linux", "GOARCH=amd64", "CGO_ENABLED=0", "GOTOOLCHAIN=local")
+		out, err := cmd.CombinedOutput()
+		r.NoError(err, string(out))
+
+		f, err := Open(exe)
+		r.NoError(err)
+		defer f.Close()
+
+		v, err := f.ModuleGoVersion()
+		r.NoError(err)
+		r.Equal("go1.22", v)
+	})
+}
+
+func TestBuildInfoVarintFormat(t *testing.T) {
+	// The test binary is built by the current toolchain, which stores the
+	// build info with varint length prefixes.
+	r := require.New(t)
+	want, ok := debug.ReadBuildInfo()
+	if !ok {
+		t.Skip("test binary has no build info")
+	}
+	exe, err := os.Executable()
+	r.NoError(err)
+
+	f, err := Open(exe)
+	r.NoError(err)
+	defer f.Close()
+
+	r.NotNil(f.BuildInfo)
+	r.Equal(want.GoVersion, f.BuildInfo.ModInfo.GoVersion)
+	r.Equal(want.Path, f.BuildInfo.ModInfo.Path)
+	r.Equal(want.Main, f.BuildInfo.ModInfo.Main)
+}
+
+func TestBuildInfoRaw(t *testing.T) {
+	const (
+		sectionAddr = 0x1000
+		stringsAddr = 0x2000
+		version     = "go1.16.15"
+		modinfo     = "path\tgithub.com/goretk/gore/gold\n"
+	)
+	le := binary.LittleEndian
+	header := func(flags byte, ptrs ...uint64) []byte {
+		h := append([]byte("\xff Go buildinf:"), intSize64, flags)
+		for _, p := range ptrs {
+			h = le.AppendUint64(h, p)
+		}
+		return append(h, make([]byte, buildInfoHeaderSize-len(h))...)
+	}
+	// The inline format, with the header not at the start of the section.
+	inline := header(buildInfoFlagsInline)
+	inline = append(binary.AppendUvarint(inline, uint64(len(version))), version...)
+	inline = append(binary.AppendUvarint(inline, uint64(len(modinfo))), modinfo...)
+
+	// The strings data referenced by the pointer format. The string headers
+	// are followed by the string data.
+	strs := le.AppendUint64(le.AppendUint64(nil, stringsAddr+32), uint64(len(version)))
+	strs = le.AppendUint64(le.AppendUint64(strs, stringsAddr+32+uint64(len(version))), uint64(len(modinfo)))
+	strs = append(append(strs, version...), modinfo...)
+
+	pointers := header(0, stringsAddr, stringsAddr+16)
+
+	tests := []struct {
+		name    string
+		section []byte
+		addr    uint64
+		data    []byte
+		err     error
+	}{
+		{"inline", append(make([]byte, buildInfoAlign), append(inline, 0, 0, 0)...), sectionAddr + buildInfoAlign, inline, nil},
+		{"pointers", append(pointers, 0, 0, 0), sectionAddr, pointers, nil},
+		{"truncated", inline[:len(inline)-1], 0, nil, ErrNoBuildInfo},
+		{"unaligned", append(make([]byte, 1), inline...), 0, nil, ErrNoBuildInfo},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			fh := &mockFileHandler{
+				mGetSectionData: func(name string) (uint64, []byte, error) {
+					if name == ".go.buildinfo" {
+						return sectionAddr, test.section, nil
+					}
+					return 0, nil, ErrSectionDoesNotExist
+				},
+				mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+					if a >= stringsAddr && a < stringsAddr+uint64(len(strs)) {
+						return stringsAddr, strs, nil
+					}
+					return 0, nil, ErrSectionDoesNotExist
+				},
+			}
+			f := newTestGoFile(fh, &FileInfo{ByteOrder: le, WordSize: intSize64})
+
+			raw, err := f.BuildInfoRaw()
+			if test.err != nil {
+				r.ErrorIs(err, test.err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(test.addr, raw.Address)
+			r.Equal(test.data, raw.Data)
+			r.Equal(version, string(raw.Version))
+			r.Equal(modinfo, string(raw.ModInfo))
+		})
+	}
+}
+
+func TestBuildInfoFromRawFallback(t *testing.T) {
+	const (
+		sectionAddr = 0x1000
+		stringsAddr = 0x2000
+		version     = "go1.16.15"
+	)
+	modinfo := string(modInfoStart) + "path\tgithub.com/goretk/gore/gold\nmod\tgithub.com/goretk/gore/gold\t(devel)\t\n" + string(modInfoEnd)
+	le := binary.LittleEndian
+	header := func(flags byte, ptrs ...uint64) []byte {
+		h := append([]byte("\xff Go buildinf:"), intSize64, flags)
+		for _, p := range ptrs {
+			h = le.AppendUint64(h, p)
+		}
+		return append(h, make([]byte, buildInfoHeaderSize-len(h))...)
+	}
+	inline := header(buildInfoFlagsInline)
+	inline = append(binary.AppendUvarint(inline, uint64(len(version))), version...)
+	inline = append(binary.AppendUvarint(inline, uint64(len(modinfo))), modinfo...)
+
+	strs := le.AppendUint64(le.AppendUint64(nil, stringsAddr+32), uint64(len(version)))
+	strs = le.AppendUint64(le.AppendUint64(strs, stringsAddr+32+uint64(len(version))), uint64(len(modinfo)))
+	strs = append(append(strs, version...), modinfo...)
+
+	tests := []struct {
+		name    string
+		section []byte
+		path    string
+	}{
+		{"inline", inline, "github.com/goretk/gore/gold"},
+		{"pointers", header(0, stringsAddr, stringsAddr+16), "github.com/goretk/gore/gold"},
+		// Binaries built outside of a module have no module information.
+		{"no modinfo", append(binary.AppendUvarint(header(buildInfoFlagsInline), uint64(len(version))), append([]byte(version), 0)...), ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			fh := &mockFileHandler{
+				// The standard library can't read the file.
+				mGetReader: func() io.ReaderAt { return bytes.NewReader([]byte("not an executable")) },
+				mGetSectionData: func(name string) (uint64, []byte, error) {
+					if name == "__data" {
+						return sectionAddr, test.section, nil
+					}
+					return 0, nil, ErrSectionDoesNotExist
+				},
+				mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+					if a >= stringsAddr && a < stringsAddr+uint64(len(strs)) {
+						return stringsAddr, strs, nil
+					}
+					return 0, nil, ErrSectionDoesNotExist
+				},
+			}
+			f := newTestGoFile(fh, &FileInfo{ByteOrder: le, WordSize: intSize64})
+
+			bi, source, err := f.extractBuildInfo()
+			r.NoError(err)
+			r.Equal(VersionSourceBuildInfo, source)
+			r.Equal(version, bi.ModInfo.GoVersion)
+			r.Equal(test.path, bi.ModInfo.Path)
+			r.NotNil(bi.Compiler)
+			r.Equal(version, bi.Compiler.Name)
+		})
+	}
+
+	t.Run("invalid modinfo", func(t *testing.T) {
+		section := header(buildInfoFlagsInline)
+		section = append(binary.AppendUvarint(section, uint64(len(version))), version...)
+		section = append(binary.AppendUvarint(section, 4), "path"...)
+		fh := &mockFileHandler{
+			mGetSectionData: func(name string) (uint64, []byte, error) {
+				if name == ".go.buildinfo" {
+					return sectionAddr, section, nil
+				}
+				return 0, nil, ErrSectionDoesNotExist
+			},
+		}
+		_, err := newTestGoFile(fh, &FileInfo{ByteOrder: le, WordSize: intSize64}).buildInfoFromRaw()
+		require.ErrorContains(t, err, "not wrapped in the markers")
+	})
+}
+
+func TestScanModInfo(t *testing.T) {
+	modinfo := "path\tgithub.com/goretk/gore/gold\nmod\tgithub.com/goretk/gore/gold\t(devel)\t\n"
+	wrap := func(s string) []byte {
+		return append(append(append([]byte{}, modInfoStart...), s...), modInfoEnd...)
+	}
+	tests := []struct {
+		name   string
+		rodata []byte
+		found  bool
+	}{
+		{"found", append([]byte("other data"), wrap(modinfo)...), true},
+		{"after invalid", append(wrap("path\tno newline"), wrap(modinfo)...), true},
+		{"no end marker", append([]byte{}, wrap(modinfo)[:len(modInfoStart)+len(modinfo)]...), false},
+		{"missing", []byte("other data"), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			fh := &mockFileHandler{
+				mGetSectionData: func(name string) (uint64, []byte, error) {
+					if name == ".rodata" {
+						return 0x1000, test.rodata, nil
+					}
+					return 0, nil, ErrSectionDoesNotExist
+				},
+			}
+			info, err := newTestGoFile(fh, nil).scanModInfo()
+			if !test.found {
+				r.ErrorIs(err, ErrNoBuildInfo)
+				return
+			}
+			r.NoError(err)
+			r.Equal("github.com/goretk/gore/gold", info.Path)
+			r.Equal("(devel)", info.Main.Version)
+		})
+	}
+}
+
+func TestBuildInfoWithoutHeader(t *testing.T) {
+	r := require.New(t)
+	want, ok := debug.ReadBuildInfo()
+	if !ok {
+		t.Skip("test binary has no build info")
+	}
+	exe, err := os.Executable()
+	r.NoError(err)
+	buf, err := os.ReadFile(exe)
+	r.NoError(err)
+
+	// Remove the buildinfo header's magic so the structure can't be found.
+	buf = bytes.ReplaceAll(buf, []byte("\xff Go buildinf:"), make([]byte, 14))
+	f, err := OpenReader(bytes.NewReader(buf))
+	r.NoError(err)
+	defer f.Close()
+
+	r.NotNil(f.BuildInfo)
+	r.Equal(want.Path, f.BuildInfo.ModInfo.Path)
+	r.Equal(want.Main, f.BuildInfo.ModInfo.Main)
+}
+
+// newStringSymbolsHandler returns a file handler with the symbols of string
+// variables holding the values.
+func newStringSymbolsHandler(values map[string]string) *mockFileHandler {
+	const base = 0x1000
+	le := binary.LittleEndian
+	syms := make(map[string]Symbol)
+	var headers, strs []byte
+	for name, v := range values {
+		syms[name] = Symbol{Name: name, Value: base + uint64(len(headers))}
+		headers = le.AppendUint64(headers, uint64(len(strs)))
+		headers = le.AppendUint64(headers, uint64(len(v)))
+		strs = append(strs, v...)
+	}
+	// The string pointers are relative to the string data that follows the
+	// headers.
+	for i := 0; i < len(headers); i += 16 {
+		le.PutUint64(headers[i:], le.Uint64(headers[i:])+base+uint64(len(headers)))
+	}
+	data := append(headers, strs...)
+	return &mockFileHandler{
+		mGetSymbol: func(name string) (Symbol, error) {
+			if s, ok := syms[name]; ok {
+				return s, nil
+			}
+			return Symbol{}, ErrSymbolNotFound
+		},
+		mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+			if a < base || a >= base+uint64(len(data)) {
+				return 0, nil, ErrSectionDoesNotExist
+			}
+			return base, data, nil
+		},
+	}
+}
+
+func TestBuildInfoFromSymbols(t *testing.T) {
+	modinfo := "path\tgithub.com/goretk/gore/gold\nmod\tgithub.com/goretk/gore/gold\t(devel)\t\n"
+	wrapped := string(modInfoStart) + modinfo + string(modInfoEnd)
+	fi := &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64}
+
+	t.Run("symbols", func(t *testing.T) {
+		r := require.New(t)
+		fh := newStringSymbolsHandler(map[string]string{"runtime.buildVersion": "go1.22.8", "runtime.modinfo": wrapped})
+		info, err := newTestGoFile(fh, fi).buildInfoFromSymbols()
+		r.NoError(err)
+		r.Equal("go1.22.8", info.GoVersion)
+		r.Equal("github.com/goretk/gore/gold", info.Path)
+		r.Equal("(devel)", info.Main.Version)
+	})
+
+	t.Run("no markers", func(t *testing.T) {
+		fh := newStringSymbolsHandler(map[string]string{"runtime.buildVersion": "go1.22.8", "runtime.modinfo": modinfo})
+		_, err := newTestGoFile(fh, fi).buildInfoFromSymbols()
+		require.Error(t, err)
+	})
+
+	t.Run("no symbol", func(t *testing.T) {
+		fh := newStringSymbolsHandler(map[string]string{"runtime.buildVersion": "go1.22.8"})
+		_, err := newTestGoFile(fh, fi).buildInfoFromSymbols()
+		require.ErrorIs(t, err, ErrSymbolNotFound)
+	})
+}
+
+func TestTamperedBuildInfoVersion(t *testing.T) {
+	r := require.New(t)
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("No go tool chain found")
+	}
+	tmpdir := t.TempDir()
+	src := filepath.Join(tmpdir, "a.go")
+	r.NoError(os.WriteFile(src, []byte(testresourcesrc), 0644))
+
+	// The binary keeps its symbol table so runtime.buildVersion can be read.
+	exe := filepath.Join(tmpdir, "a")
+	cmd := exec.Command(goBin, "build", "-o", exe, src)
+	cmd.Env = append(os.Environ(), "GOOS=linux", "GOARCH=amd64", "CGO_ENABLED=0")
+	out, err := cmd.CombinedOutput()
+	r.NoError(err, string(out))
+
+	// Replace the version in the buildinfo structure with an older one of
+	// the same length. It follows the header as a length prefixed string.
+	data, err := os.ReadFile(exe)
+	r.NoError(err)
+	hdr := bytes.Index(data, buildInfoMagic)
+	r.NotEqual(-1, hdr)
+	l, n := binary.Uvarint(data[hdr+buildInfoHeaderSize:])
+	r.Greater(n, 0)
+	r.GreaterOrEqual(l, uint64(len("go1.19.9")))
+	tampered := "go1.19." + strings.Repeat("9", int(l)-len("go1.19."))
+	copy(data[hdr+buildInfoHeaderSize+n:], tampered)
+	r.NoError(os.WriteFile(exe, data, 0755))
+
+	f, err := Open(exe)
+	r.NoError(err)
+	defer f.Close()
+
+	// The buildinfo structure is preferred over the symbols.
+	r.NotNil(f.BuildInfo)
+	r.Equal(tampered, f.BuildInfo.ModInfo.GoVersion)
+	source, err := f.CompilerVersionSource()
+	r.NoError(err)
+	r.Equal(VersionSourceBuildInfo, source)
+
+	buildVersion, err := f.readStringSymbol("runtime.buildVersion")
+	r.NoError(err)
+	r.NotEqual(tampered, buildVersion)
+
+	raw, err := f.BuildInfoRaw()
+	r.NoError(err)
+	r.Equal(tampered, string(raw.Version))
+
+	// Without the buildinfo header, the symbols are used.
+	copy(data[hdr:], make([]byte, len(buildInfoMagic)))
+	r.NoError(os.WriteFile(exe, data, 0755))
+
+	f, err = Open(exe)
+	r.NoError(err)
+	defer f.Close()
+
+	r.NotNil(f.BuildInfo)
+	r.Equal(buildVersion, f.BuildInfo.ModInfo.GoVersion)
+	// The compiler version is only resolved for known Go versions.
+	if f.BuildInfo.Compiler != nil {
+		source, err = f.CompilerVersionSource()
+		r.NoError(err)
+		r.Equal(VersionSourceSymbol, source)
+	}
+}
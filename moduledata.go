@@ -35,6 +35,9 @@ import (
 type Moduledata interface {
 	// Text returns the text secion.
 	Text() ModuleDataSection
+	// TextSections returns the text sections. Large binaries can have the code
+	// split into multiple sections.
+	TextSections() ([]ModuleDataSection, error)
 	// NoPtrData returns the noptrdata section.
 	NoPtrData() ModuleDataSection
 	// Data returns the data section.
@@ -57,6 +60,12 @@ type Moduledata interface {
 	TypeLinkData() ([]int32, error)
 	// GoFuncValue returns the value of the 'go:func.*' symbol.
 	GoFuncValue() uint64
+	// PackageHashes returns the hashes of the packages a plugin was built
+	// against.
+	PackageHashes() ([]ModuleHash, error)
+	// ModuleHashes returns the hashes of the shared libraries the module was
+	// linked against.
+	ModuleHashes() ([]ModuleHash, error)
 }
 
 type moduledata struct {
@@ -66,14 +75,25 @@ type moduledata struct {
 	BssAddr, BssLen             uint64
 	NoPtrBssAddr, NoPtrBssLen   uint64
 
-	TypesAddr, TypesLen       uint64
-	TypelinkAddr, TypelinkLen uint64
-	ITabLinkAddr, ITabLinkLen uint64
-	FuncTabAddr, FuncTabLen   uint64
-	PCLNTabAddr, PCLNTabLen   uint64
+	TypesAddr, TypesLen             uint64
+	TextSectMapAddr, TextSectMapLen uint64
+	TypelinkAddr, TypelinkLen       uint64
+	ITabLinkAddr, ITabLinkLen       uint64
+	FuncTabAddr, FuncTabLen         uint64
+	PCLNTabAddr, PCLNTabLen         uint64
 
 	GoFuncVal uint64
 
+	PkgHashesAddr, PkgHashesLen       uint64
+	ModuleHashesAddr, ModuleHashesLen uint64
+
+	InitTasksAddr, InitTasksLen uint64
+
+	// rawAddr and raw are the address and the bytes of the structure the
+	// moduledata was read from.
+	rawAddr uint64
+	raw     []byte
+
 	fh fileHandler
 }
 
@@ -86,6 +106,44 @@ func (m moduledata) Text() ModuleDataSection {
 	}
 }
 
+// TextSections returns the text sections listed in the moduledata's
+// "textsectmap". The linker splits the code into multiple sections if it's
+// too large to be reached by the branch instructions of the architecture.
+// For binaries without the map, the section returned by Text is returned.
+func (m moduledata) TextSections() ([]ModuleDataSection, error) {
+	if m.TextSectMapLen == 0 {
+		return []ModuleDataSection{m.Text()}, nil
+	}
+	base, data, err := m.fh.getSectionDataFromAddress(m.TextSectMapAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the textsectmap data section: %w", err)
+	}
+
+	fi := m.fh.getFileInfo()
+	r := bytes.NewReader(data[m.TextSectMapAddr-base:])
+	sects := make([]ModuleDataSection, 0, m.TextSectMapLen)
+	for i := uint64(0); i < m.TextSectMapLen; i++ {
+		// Each entry holds the start and end offset of the section relative
+		// to runtime.text and the section's relocated address.
+		var entry [3]uint64
+		for j := range entry {
+			entry[j], err = readUIntTo64(r, fi.ByteOrder, fi.WordSize == intSize32)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read textsectmap item %d: %w", i, err)
+			}
+		}
+		if entry[1] < entry[0] {
+			return nil, fmt.Errorf("textsectmap item %d has an invalid range", i)
+		}
+		sects = append(sects, ModuleDataSection{
+			Address: m.TextAddr + entry[0],
+			Length:  entry[1] - entry[0],
+			fh:      m.fh,
+		})
+	}
+	return sects, nil
+}
+
 // NoPtrData returns the noptrdata section.
 func (m moduledata) NoPtrData() ModuleDataSection {
 	return ModuleDataSection{
@@ -196,6 +254,103 @@ func (m moduledata) GoFuncValue() uint64 {
 	return m.GoFuncVal
 }
 
+// ModuleHash is the hash of a package or a shared library that is checked
+// when a plugin or a shared library is loaded. The runtime compares the hash
+// seen by the linker with the hash stored in the loaded package or library.
+type ModuleHash struct {
+	// ModuleName is the package's path for a package hash and the shared
+	// library's name for a module hash.
+	ModuleName string
+	// LinkTimeHash is the hash seen by the linker, as raw bytes.
+	LinkTimeHash string
+	// RuntimeHash is the hash stored in the package or the library, as raw
+	// bytes. It's empty if it isn't stored in this file.
+	RuntimeHash string
+}
+
+// PackageHashes returns the hashes of the packages listed in the moduledata's
+// "pkghashes". The table is only filled for plugins, where it holds the hashes
+// of the packages the plugin was built against. These must match the packages
+// in the program loading the plugin.
+func (m moduledata) PackageHashes() ([]ModuleHash, error) {
+	hashes, err := m.readModuleHashes(m.PkgHashesAddr, m.PkgHashesLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the pkghashes: %w", err)
+	}
+	return hashes, nil
+}
+
+// ModuleHashes returns the hashes of the shared libraries listed in the
+// moduledata's "modulehashes". The table is only filled for binaries built
+// with "-linkshared".
+func (m moduledata) ModuleHashes() ([]ModuleHash, error) {
+	hashes, err := m.readModuleHashes(m.ModuleHashesAddr, m.ModuleHashesLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the modulehashes: %w", err)
+	}
+	return hashes, nil
+}
+
+func (m moduledata) readModuleHashes(addr, n uint64) ([]ModuleHash, error) {
+	if n == 0 {
+		return nil, nil
+	}
+	base, data, err := m.fh.getSectionDataFromAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	fi := m.fh.getFileInfo()
+	r := bytes.NewReader(data[addr-base:])
+	hashes := make([]ModuleHash, 0, n)
+	for i := uint64(0); i < n; i++ {
+		// Each entry holds the name and the link time hash strings followed
+		// by a pointer to the runtime hash string.
+		var entry [5]uint64
+		for j := range entry {
+			entry[j], err = readUIntTo64(r, fi.ByteOrder, fi.WordSize == intSize32)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read item %d: %w", i, err)
+			}
+		}
+		name, err := m.readString(entry[0], entry[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the name of item %d: %w", i, err)
+		}
+		linkHash, err := m.readString(entry[2], entry[3])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the link time hash of item %d: %w", i, err)
+		}
+		hash := ModuleHash{ModuleName: name, LinkTimeHash: linkHash}
+
+		// The runtime hash is located in the package or the library the hash
+		// is for, so it can't be read if it's in another file.
+		if hdr, err := m.readString(entry[4], 2*uint64(fi.WordSize)); err == nil {
+			hr := bytes.NewReader([]byte(hdr))
+			ptr, _ := readUIntTo64(hr, fi.ByteOrder, fi.WordSize == intSize32)
+			l, _ := readUIntTo64(hr, fi.ByteOrder, fi.WordSize == intSize32)
+			hash.RuntimeHash, _ = m.readString(ptr, l)
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// readString returns the length bytes at the address as a string.
+func (m moduledata) readString(addr, length uint64) (string, error) {
+	if length == 0 {
+		return "", nil
+	}
+	base, data, err := m.fh.getSectionDataFromAddress(addr)
+	if err != nil {
+		return "", err
+	}
+	if length > uint64(len(data)) || addr-base > uint64(len(data))-length {
+		return "", errors.New("length out of bounds")
+	}
+	return string(data[addr-base : addr-base+length]), nil
+}
+
 // ModuleDataSection is a section defined in the Moduledata structure.
 type ModuleDataSection struct {
 	// Address is the virtual address where the section starts.
@@ -276,19 +431,35 @@ func extractModuledata(f *GoFile) (moduledata, error) {
 	var off int
 	var magic []byte
 	var tabAddr uint64
+	// skipped is the number of bytes dropped from the start of the section
+	// data after false positive matches.
+	var skipped int
+
+	// fromSymbol is true if the candidate was located with the symbol table.
+	var fromSymbol bool
 
 	secAddr, secData, err := f.fh.getSectionData(f.fh.moduledataSection())
 	if err != nil {
 		return moduledata{}, err
 	}
-
-	// if we can get the moduledata addr from the symbol, we have no need to search
-	sym, err := f.fh.getSymbol("runtime.firstmoduledata")
-	if err == nil {
-		off = int(sym.Value - secAddr)
-		goto load
+	mdSecAddr, mdSecData := secAddr, secData
+
+	// If we can get the moduledata addr from the symbol, we have no need to search.
+	// The symbol is looked up in the section that holds it since external linkers
+	// may not place it in the section we search.
+	if f.fh.hasSymbolTable() {
+		if sym, err := f.fh.getSymbol("runtime.firstmoduledata"); err == nil {
+			addr, data, err := f.fh.getSectionDataFromAddress(sym.Value)
+			if err == nil && sym.Value >= addr && sym.Value-addr+uint64(vmdSize) <= uint64(len(data)) {
+				fromSymbol = true
+				secAddr, secData = addr, data
+				off = int(sym.Value - addr)
+				goto load
+			}
+		}
 	}
 
+scan:
 	err = f.initPclntab()
 	if err != nil {
 		return moduledata{}, err
@@ -335,13 +506,37 @@ load:
 		goto invalidMD
 	}
 
+	// Since Go 1.7, the moduledata also holds the bounds of the types data. A false
+	// positive match can pass the text check above by chance, so we also ensure
+	// that the types data is located within a section in the file.
+	if GoVersionCompare(f.FileInfo.goversion.Name, "go1.7beta1") >= 0 {
+		types := md.TypesAddr
+		etypes := md.TypesAddr + md.TypesLen
+		if types >= etypes {
+			goto invalidMD
+		}
+		typesSectAddr, typesSect, err := f.fh.getSectionDataFromAddress(types)
+		if err != nil || etypes > typesSectAddr+uint64(len(typesSect)) {
+			goto invalidMD
+		}
+	}
+
 	// Add the file handler.
 	md.fh = f.fh
+	md.rawAddr = secAddr + uint64(skipped+off)
+	md.raw = data
 
 	return md, nil
 
 invalidMD:
+	if fromSymbol {
+		// The symbol doesn't point to a valid moduledata, search for it instead.
+		fromSymbol = false
+		secAddr, secData = mdSecAddr, mdSecData
+		goto scan
+	}
 	secData = secData[off+1:]
+	skipped += off + 1
 	goto search
 }
 
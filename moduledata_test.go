@@ -18,6 +18,8 @@
 package gore
 
 import (
+	"bytes"
+	"encoding/binary"
 	"path/filepath"
 	"testing"
 
@@ -91,3 +93,268 @@ func TestModuledata(t *testing.T) {
 		})
 	}
 }
+
+func TestModuledataTypesBounds(t *testing.T) {
+	const (
+		textAddr    = 0x1000
+		typesAddr   = 0x2000
+		dataAddr    = 0x3000
+		pclntabAddr = 0x5000
+	)
+	sections := map[uint64][]byte{
+		textAddr:  make([]byte, 0x100),
+		typesAddr: make([]byte, 0x100),
+	}
+
+	newMD := func(types, etypes, noptrdata uint64) moduledata_1_22_64 {
+		return moduledata_1_22_64{
+			PcHeader:  pclntabAddr,
+			Text:      textAddr,
+			Etext:     textAddr + 0x100,
+			Noptrdata: noptrdata,
+			Types:     types,
+			Etypes:    etypes,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		candidate moduledata_1_22_64
+	}{
+		{"types end outside of section", newMD(typesAddr, typesAddr+0x1000, 0x1111)},
+		{"types end before start", newMD(typesAddr+0x80, typesAddr, 0x1111)},
+		{"types not in a section", newMD(0x9000, 0x9080, 0x1111)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+
+			// The section holds a false positive followed by the real moduledata.
+			buf := &bytes.Buffer{}
+			r.NoError(binary.Write(buf, binary.LittleEndian, test.candidate))
+			r.NoError(binary.Write(buf, binary.LittleEndian, newMD(typesAddr, typesAddr+0x80, 0x2222)))
+			data := buf.Bytes()
+
+			fi := &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64, goversion: ResolveGoVersion("go1.22.0")}
+			f := newTestGoFile(&mockFileHandler{
+				mGetSymbol: func(string) (Symbol, error) {
+					return Symbol{}, ErrSymbolNotFound
+				},
+				mModuledataSection: func() string { return ".noptrdata" },
+				mGetSectionData: func(string) (uint64, []byte, error) {
+					return dataAddr, data, nil
+				},
+				mGetCodeSection: func() (uint64, []byte, error) {
+					return textAddr, sections[textAddr], nil
+				},
+				mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+					for addr, sect := range sections {
+						if addr <= a && a < addr+uint64(len(sect)) {
+							return addr, sect, nil
+						}
+					}
+					return 0, nil, ErrSectionDoesNotExist
+				},
+			}, fi)
+			f.pclntabAddr = pclntabAddr
+
+			md, err := extractModuledata(f)
+			r.NoError(err)
+			r.Equal(uint64(0x2222), md.NoPtrDataAddr, "the false positive should be skipped")
+
+			f.moduledata = md
+			addr, raw, err := f.RawModuledata()
+			r.NoError(err)
+			size := binary.Size(moduledata_1_22_64{})
+			r.Equal(uint64(dataAddr+size), addr)
+			r.Equal(data[size:], raw)
+		})
+	}
+}
+
+func TestModuledataTextSections(t *testing.T) {
+	const (
+		textAddr    = 0x1000
+		sectMapAddr = 0x8000
+	)
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.LittleEndian, []uint64{
+		0, 0x1000, textAddr,
+		0x1000, 0x1800, textAddr + 0x1000,
+	})
+	fh := &mockFileHandler{
+		mGetFileInfo: func() *FileInfo {
+			return &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64}
+		},
+		mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+			if a == sectMapAddr {
+				return sectMapAddr, buf.Bytes(), nil
+			}
+			return 0, nil, ErrSectionDoesNotExist
+		},
+	}
+
+	t.Run("textsectmap", func(t *testing.T) {
+		r := require.New(t)
+		md := moduledata{TextAddr: textAddr, TextLen: 0x1800, TextSectMapAddr: sectMapAddr, TextSectMapLen: 2, fh: fh}
+		sects, err := md.TextSections()
+		r.NoError(err)
+		r.Len(sects, 2)
+		r.Equal(uint64(textAddr), sects[0].Address)
+		r.Equal(uint64(0x1000), sects[0].Length)
+		r.Equal(uint64(textAddr+0x1000), sects[1].Address)
+		r.Equal(uint64(0x800), sects[1].Length)
+	})
+
+	t.Run("truncated textsectmap", func(t *testing.T) {
+		md := moduledata{TextAddr: textAddr, TextSectMapAddr: sectMapAddr, TextSectMapLen: 3, fh: fh}
+		_, err := md.TextSections()
+		require.Error(t, err)
+	})
+
+	t.Run("no textsectmap", func(t *testing.T) {
+		r := require.New(t)
+		md := moduledata{TextAddr: textAddr, TextLen: 0x1800, fh: fh}
+		sects, err := md.TextSections()
+		r.NoError(err)
+		r.Equal([]ModuleDataSection{md.Text()}, sects)
+	})
+}
+
+func TestModuledataModuleHashes(t *testing.T) {
+	const dataAddr = 0x8000
+	data := make([]byte, 0x300)
+	le := binary.LittleEndian
+	copy(data[0x100:], "github.com/foo/barlinkhash1stdlib.solinkhash2")
+	copy(data[0x210:], "runhash1")
+	// The string header of the runtime hash.
+	le.PutUint64(data[0x200:], dataAddr+0x210)
+	le.PutUint64(data[0x208:], 8)
+	for i, v := range []uint64{
+		dataAddr + 0x100, 18, dataAddr + 0x112, 9, dataAddr + 0x200,
+		dataAddr + 0x11b, 9, dataAddr + 0x124, 9, 0,
+	} {
+		le.PutUint64(data[i*8:], v)
+	}
+	fh := &mockFileHandler{
+		mGetFileInfo: func() *FileInfo {
+			return &FileInfo{ByteOrder: le, WordSize: intSize64}
+		},
+		mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+			if a >= dataAddr && a < dataAddr+uint64(len(data)) {
+				return dataAddr, data, nil
+			}
+			return 0, nil, ErrSectionDoesNotExist
+		},
+	}
+	expected := []ModuleHash{
+		{ModuleName: "github.com/foo/bar", LinkTimeHash: "linkhash1", RuntimeHash: "runhash1"},
+		{ModuleName: "stdlib.so", LinkTimeHash: "linkhash2"},
+	}
+
+	t.Run("pkghashes", func(t *testing.T) {
+		md := moduledata{PkgHashesAddr: dataAddr, PkgHashesLen: 2, fh: fh}
+		hashes, err := md.PackageHashes()
+		require.NoError(t, err)
+		require.Equal(t, expected, hashes)
+	})
+
+	t.Run("modulehashes", func(t *testing.T) {
+		md := moduledata{ModuleHashesAddr: dataAddr, ModuleHashesLen: 2, fh: fh}
+		hashes, err := md.ModuleHashes()
+		require.NoError(t, err)
+		require.Equal(t, expected, hashes)
+	})
+
+	t.Run("no hashes", func(t *testing.T) {
+		md := moduledata{fh: fh}
+		hashes, err := md.ModuleHashes()
+		require.NoError(t, err)
+		require.Empty(t, hashes)
+	})
+
+	t.Run("truncated modulehashes", func(t *testing.T) {
+		md := moduledata{ModuleHashesAddr: dataAddr, ModuleHashesLen: 100, fh: fh}
+		_, err := md.ModuleHashes()
+		require.Error(t, err)
+	})
+}
+
+func TestModuledataFromSymbol(t *testing.T) {
+	const (
+		textAddr    = 0x1000
+		typesAddr   = 0x2000
+		dataAddr    = 0x3000
+		relroAddr   = 0x4000
+		pclntabAddr = 0x5000
+	)
+
+	encode := func(noptrdata uint64) []byte {
+		buf := &bytes.Buffer{}
+		require.NoError(t, binary.Write(buf, binary.LittleEndian, moduledata_1_22_64{
+			PcHeader:  pclntabAddr,
+			Text:      textAddr,
+			Etext:     textAddr + 0x100,
+			Noptrdata: noptrdata,
+			Types:     typesAddr,
+			Etypes:    typesAddr + 0x80,
+		}))
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name     string
+		symbol   uint64
+		data     []byte
+		relro    []byte
+		expected uint64
+	}{
+		// The moduledata section only holds zeros so the search can't find it.
+		{"symbol in another section", relroAddr + 8, make([]byte, 0x200), append(make([]byte, 8), encode(0x1111)...), 0x1111},
+		{"symbol not valid", relroAddr, encode(0x2222), make([]byte, 0x400), 0x2222},
+		{"symbol out of bounds", relroAddr + 0x1f0, encode(0x3333), make([]byte, 0x200), 0x3333},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			sections := map[uint64][]byte{
+				textAddr:  make([]byte, 0x100),
+				typesAddr: make([]byte, 0x100),
+				dataAddr:  test.data,
+				relroAddr: test.relro,
+			}
+
+			fi := &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64, goversion: ResolveGoVersion("go1.22.0")}
+			f := newTestGoFile(&mockFileHandler{
+				mGetSymbol: func(name string) (Symbol, error) {
+					if name != "runtime.firstmoduledata" {
+						return Symbol{}, ErrSymbolNotFound
+					}
+					return Symbol{Name: name, Value: test.symbol}, nil
+				},
+				mModuledataSection: func() string { return ".noptrdata" },
+				mGetSectionData: func(string) (uint64, []byte, error) {
+					return dataAddr, test.data, nil
+				},
+				mGetCodeSection: func() (uint64, []byte, error) {
+					return textAddr, sections[textAddr], nil
+				},
+				mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+					for addr, sect := range sections {
+						if addr <= a && a < addr+uint64(len(sect)) {
+							return addr, sect, nil
+						}
+					}
+					return 0, nil, ErrSectionDoesNotExist
+				},
+			}, fi)
+			f.pclntabAddr = pclntabAddr
+
+			md, err := extractModuledata(f)
+			r.NoError(err)
+			r.Equal(test.expected, md.NoPtrDataAddr)
+		})
+	}
+}
@@ -43,6 +43,82 @@ type Package struct {
 	Methods []*Method `json:"methods"`
 }
 
+// FunctionsByFile returns the functions and methods of the package grouped by
+// the path of the source file they are defined in. The functions for each file
+// are sorted by their offset. Methods are included as functions, the receiver
+// can be found by looking up the method in the package.
+func (p *Package) FunctionsByFile() map[string][]*Function {
+	files := make(map[string][]*Function)
+	add := func(fn *Function) {
+		files[fn.Filename] = append(files[fn.Filename], fn)
+	}
+	for _, fn := range p.Functions {
+		add(fn)
+	}
+	for _, m := range p.Methods {
+		add(m.Function)
+	}
+
+	for _, fns := range files {
+		sort.Slice(fns, func(i, j int) bool {
+			return fns[i].Offset < fns[j].Offset
+		})
+	}
+	return files
+}
+
+// CodeRange returns the address range of the package's code. The start is the
+// lowest offset and the end is the highest end of the package's functions and
+// methods. If the package has no functions or methods, zero is returned for
+// both.
+func (p *Package) CodeRange() (start, end uint64) {
+	first := true
+	add := func(fn *Function) {
+		if first || fn.Offset < start {
+			start = fn.Offset
+		}
+		if first || fn.End > end {
+			end = fn.End
+		}
+		first = false
+	}
+	for _, fn := range p.Functions {
+		add(fn)
+	}
+	for _, m := range p.Methods {
+		add(m.Function)
+	}
+	return start, end
+}
+
+// ModuleRelPath returns the package's path without the module version, for
+// binaries built with the "-trimpath" flag. The flag replaces the folder of
+// a package in a module with "module@version/dir", for example the path
+// "github.com/foo/bar@v1.2.3/baz" is returned as "github.com/foo/bar/baz".
+// The packages in the main module are recorded without a version and are
+// returned as they are. If the path is not trimmed, an empty string is
+// returned.
+func (p *Package) ModuleRelPath() string {
+	if !isTrimmedPath(p.Filepath) {
+		return ""
+	}
+	at := strings.Index(p.Filepath, "@")
+	if at == -1 {
+		return p.Filepath
+	}
+	end := strings.Index(p.Filepath[at:], "/")
+	if end == -1 {
+		return p.Filepath[:at]
+	}
+	return p.Filepath[:at] + p.Filepath[at+end:]
+}
+
+// isTrimmedPath returns true if the package path has been trimmed by the
+// "-trimpath" flag. Trimmed paths are import paths instead of folders.
+func isTrimmedPath(p string) bool {
+	return p != "" && p != "<autogenerated>" && !isAbsPath(p) && !strings.HasPrefix(p, ".")
+}
+
 // GetSourceFiles returns a slice of source files within the package.
 // The source files are a representations of the source code files in the package.
 func (f *GoFile) GetSourceFiles(p *Package) []*SourceFile {
@@ -54,27 +130,40 @@ func (f *GoFile) GetSourceFiles(p *Package) []*SourceFile {
 		}
 		return sf
 	}
+	// The file is recorded when the packages are enumerated, but a Package
+	// created by the caller may not have it, so fall back to the PCLN table.
+	raw, _ := f.getPCLNTable()
+	fileName := func(fn *Function) string {
+		if fn.Filename != "" {
+			return fn.Filename
+		}
+		name, _, _ := f.pclntab.PCToLine(fn.Offset)
+		if name == "" && raw != nil {
+			name = raw.funcFile(fn.Offset)
+		}
+		return name
+	}
 
 	// Sort functions and methods by source file.
 	for _, fn := range p.Functions {
-		fileName, _, _ := f.pclntab.PCToLine(fn.Offset)
 		start, end := findSourceLines(fn.Offset, fn.End, f.pclntab)
 
 		e := FileEntry{Name: fn.Name, Start: start, End: end}
 
-		sf := getSourceFile(fileName)
+		name := fileName(fn)
+		sf := getSourceFile(name)
 		sf.entries = append(sf.entries, e)
-		tmp[fileName] = sf
+		tmp[name] = sf
 	}
 	for _, m := range p.Methods {
-		fileName, _, _ := f.pclntab.PCToLine(m.Offset)
 		start, end := findSourceLines(m.Offset, m.End, f.pclntab)
 
 		e := FileEntry{Name: fmt.Sprintf("%s%s", m.Receiver, m.Name), Start: start, End: end}
 
-		sf := getSourceFile(fileName)
+		name := fileName(m.Function)
+		sf := getSourceFile(name)
 		sf.entries = append(sf.entries, e)
-		tmp[fileName] = sf
+		tmp[name] = sf
 	}
 
 	// Create final slice and populate it.
@@ -92,6 +181,59 @@ func (f *GoFile) GetSourceFiles(p *Package) []*SourceFile {
 	return files
 }
 
+// DiffPackages compares the main packages of two files. The names of the packages
+// only found in a, only found in b and found in both files are returned sorted.
+// If includeVendors is true, the vendor packages are included in the comparison.
+func DiffPackages(a, b *GoFile, includeVendors bool) (onlyA, onlyB, common []string, err error) {
+	pkgsA, err := diffPackageNames(a, includeVendors)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get the packages of the first file: %w", err)
+	}
+	pkgsB, err := diffPackageNames(b, includeVendors)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get the packages of the second file: %w", err)
+	}
+
+	for name := range pkgsA {
+		if _, ok := pkgsB[name]; ok {
+			common = append(common, name)
+		} else {
+			onlyA = append(onlyA, name)
+		}
+	}
+	for name := range pkgsB {
+		if _, ok := pkgsA[name]; !ok {
+			onlyB = append(onlyB, name)
+		}
+	}
+
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+	sort.Strings(common)
+	return onlyA, onlyB, common, nil
+}
+
+// diffPackageNames returns the set of package names used by DiffPackages.
+func diffPackageNames(f *GoFile, includeVendors bool) (map[string]struct{}, error) {
+	pkgs, err := f.GetPackages()
+	if err != nil {
+		return nil, err
+	}
+	if includeVendors {
+		vendors, err := f.GetVendors()
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs[:len(pkgs):len(pkgs)], vendors...)
+	}
+
+	names := make(map[string]struct{}, len(pkgs))
+	for _, p := range pkgs {
+		names[p.Name] = struct{}{}
+	}
+	return names, nil
+}
+
 // PackageClass is a type used to indicate the package kind.
 type PackageClass uint8
 
@@ -121,6 +263,7 @@ func NewPathPackageClassifier(mainPkgFilepath string) *PathPackageClassifier {
 			path.Dir(mainPkgFilepath),
 			path.Clean(mainPkgFilepath),
 		},
+		trimmed: isTrimmedPath(mainPkgFilepath),
 	}
 }
 
@@ -128,6 +271,13 @@ func NewPathPackageClassifier(mainPkgFilepath string) *PathPackageClassifier {
 type PathPackageClassifier struct {
 	mainFilepath string
 	mainFolders  []string
+	// trimmed is true if the binary was built with the "-trimpath" flag.
+	trimmed bool
+	// mainModule is the path of the main module, if known. The packages of
+	// trimmed binaries under it are classified as main.
+	mainModule string
+	// stdPkgs replaces the default standard library package set, if set.
+	stdPkgs map[string]struct{}
 }
 
 // Classify returns the package class for the package.
@@ -136,7 +286,7 @@ func (c *PathPackageClassifier) Classify(pkg *Package) PackageClass {
 		return ClassGenerated
 	}
 
-	if IsStandardLibrary(pkg.Name) {
+	if isStandardLibrary(c.stdPkgs, pkg.Name) {
 		return ClassSTD
 	}
 
@@ -146,7 +296,7 @@ func (c *PathPackageClassifier) Classify(pkg *Package) PackageClass {
 
 	// Detect internal/golang.org/x/net/http2/hpack type/
 	tmp := strings.Split(pkg.Name, "/golang.org")[0]
-	if len(tmp) < len(pkg.Name) && IsStandardLibrary(tmp) {
+	if len(tmp) < len(pkg.Name) && isStandardLibrary(c.stdPkgs, tmp) {
 		return ClassSTD
 	}
 
@@ -160,6 +310,17 @@ func (c *PathPackageClassifier) Classify(pkg *Package) PackageClass {
 		return ClassVendor
 	}
 
+	// With "-trimpath", the packages in the main module are recorded without
+	// a module version. Other packages can be too, for example in GOPATH mode
+	// or for modules replaced with a local folder, so only the packages under
+	// the main module's path are classified here. Vendored packages keep the
+	// vendor folder.
+	if c.trimmed && c.mainModule != "" && isTrimmedPath(pkg.Filepath) &&
+		(pkg.Name == c.mainModule || strings.HasPrefix(pkg.Name, c.mainModule+"/")) &&
+		!strings.Contains(pkg.Filepath, "/vendor/") {
+		return ClassMain
+	}
+
 	parentFolder := path.Dir(pkg.Filepath)
 
 	if strings.HasPrefix(pkg.Filepath, c.mainFilepath+"/vendor/") ||
@@ -215,6 +376,26 @@ func (c *PathPackageClassifier) Classify(pkg *Package) PackageClass {
 	return ClassUnknown
 }
 
+// NewUnknownAsMainClassifier returns a classifier that classifies the packages
+// the given classifier can't classify as main packages.
+func NewUnknownAsMainClassifier(classifier PackageClassifier) PackageClassifier {
+	return &unknownAsMainClassifier{classifier: classifier}
+}
+
+// unknownAsMainClassifier classifies the packages the wrapped classifier
+// returns ClassUnknown for as ClassMain.
+type unknownAsMainClassifier struct {
+	classifier PackageClassifier
+}
+
+// Classify returns the package class for the package.
+func (c *unknownAsMainClassifier) Classify(pkg *Package) PackageClass {
+	if class := c.classifier.Classify(pkg); class != ClassUnknown {
+		return class
+	}
+	return ClassMain
+}
+
 // IsStandardLibrary returns true if the package is from the standard library.
 // Otherwise, false is retuned.
 func IsStandardLibrary(pkg string) bool {
@@ -222,6 +403,16 @@ func IsStandardLibrary(pkg string) bool {
 	return ok
 }
 
+// isStandardLibrary returns true if the package is in the set of standard
+// library packages. If the set is nil, the default set is used.
+func isStandardLibrary(set map[string]struct{}, pkg string) bool {
+	if set == nil {
+		return IsStandardLibrary(pkg)
+	}
+	_, ok := set[pkg]
+	return ok
+}
+
 func isGeneratedPackage(pkg *Package) bool {
 	if pkg.Filepath == "<autogenerated>" {
 		return true
@@ -248,11 +439,13 @@ func NewModPackageClassifier(buildInfo *debug.BuildInfo) *ModPackageClassifier {
 // ModPackageClassifier uses the mod info extracted from the binary to classify packages.
 type ModPackageClassifier struct {
 	modInfo *debug.BuildInfo
+	// stdPkgs replaces the default standard library package set, if set.
+	stdPkgs map[string]struct{}
 }
 
 // Classify performs the classification.
 func (c *ModPackageClassifier) Classify(pkg *Package) PackageClass {
-	if IsStandardLibrary(pkg.Name) {
+	if isStandardLibrary(c.stdPkgs, pkg.Name) {
 		return ClassSTD
 	}
 
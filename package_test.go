@@ -20,7 +20,10 @@ package gore
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime/debug"
 	"sort"
 	"testing"
 
@@ -480,6 +483,155 @@ func TestSubSubSubPackage(t *testing.T) {
 	}
 }
 
+func TestTrimpathPackageClassification(t *testing.T) {
+	r := require.New(t)
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("No go tool chain found")
+	}
+
+	// The main module has a subpackage and depends on a module replaced
+	// with a local folder, so neither is recorded with a module version.
+	tmpdir := t.TempDir()
+	files := map[string]string{
+		"app/go.mod": "module example.com/app\n\ngo 1.21\n\n" +
+			"require example.com/lib v0.0.0\n\nreplace example.com/lib => ../lib\n",
+		"app/main.go": "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/app/internal/x\"\n)\n\n" +
+			"func main() { fmt.Println(x.X()) }\n",
+		"app/internal/x/x.go": "package x\n\nimport \"example.com/lib\"\n\n" +
+			"//go:noinline\nfunc X() string { return lib.Hello() + \"x\" }\n",
+		"lib/go.mod": "module example.com/lib\n\ngo 1.21\n",
+		"lib/lib.go": "package lib\n\n//go:noinline\nfunc Hello() string { return \"hello\" }\n",
+	}
+	for name, content := range files {
+		fp := filepath.Join(tmpdir, filepath.FromSlash(name))
+		r.NoError(os.MkdirAll(filepath.Dir(fp), 0755))
+		r.NoError(os.WriteFile(fp, []byte(content), 0644))
+	}
+
+	exe := filepath.Join(tmpdir, "app.bin")
+	cmd := exec.Command(goBin, "build", "-trimpath", "-o", exe, ".")
+	cmd.Dir = filepath.Join(tmpdir, "app")
+	cmd.Env = append(os.Environ(), "GOOS=linux", "GOARCH=amd64", "CGO_ENABLED=0", "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	r.NoError(err, string(out))
+
+	f, err := Open(exe)
+	r.NoError(err)
+	defer f.Close()
+
+	pkgs, err := f.GetPackages()
+	r.NoError(err)
+	vendors, err := f.GetVendors()
+	r.NoError(err)
+
+	names := func(pkgs []*Package) []string {
+		var n []string
+		for _, p := range pkgs {
+			n = append(n, p.Name)
+		}
+		return n
+	}
+	assert.Contains(t, names(pkgs), "main")
+	assert.Contains(t, names(pkgs), "example.com/app/internal/x")
+	assert.NotContains(t, names(pkgs), "example.com/lib")
+	assert.Contains(t, names(vendors), "example.com/lib")
+}
+
+func TestPackageModuleRelPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"github.com/foo/bar@v1.2.3/baz", "github.com/foo/bar/baz"},
+		{"github.com/foo/bar@v1.2.3", "github.com/foo/bar"},
+		{"github.com/me/app/internal/db", "github.com/me/app/internal/db"},
+		{"/home/user/go/pkg/mod/github.com/foo/bar@v1.2.3/baz", ""},
+		{"C:/Users/user/proj", ""},
+		{"<autogenerated>", ""},
+		{"", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, (&Package{Filepath: test.path}).ModuleRelPath())
+		})
+	}
+}
+
+func TestPackageCodeRange(t *testing.T) {
+	fn := func(offset, end uint64) *Function {
+		return &Function{Offset: offset, End: end}
+	}
+	tests := []struct {
+		name       string
+		pkg        *Package
+		start, end uint64
+	}{
+		{"empty", &Package{}, 0, 0},
+		{"functions", &Package{Functions: []*Function{fn(0x2000, 0x2040), fn(0x1000, 0x1080)}}, 0x1000, 0x2040},
+		{"methods", &Package{
+			Functions: []*Function{fn(0x2000, 0x2040)},
+			Methods:   []*Method{{Function: fn(0x1800, 0x1900)}, {Function: fn(0x3000, 0x3010)}},
+		}, 0x1800, 0x3010},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start, end := test.pkg.CodeRange()
+			assert.Equal(t, test.start, start)
+			assert.Equal(t, test.end, end)
+		})
+	}
+}
+
+func TestCustomStdPkgsClassification(t *testing.T) {
+	std := map[string]struct{}{"machine": {}, "runtime": {}}
+	path := NewPathPackageClassifier("/home/user/proj")
+	path.stdPkgs = std
+	mod := NewModPackageClassifier(&debug.BuildInfo{Path: "example.com/proj"})
+	mod.stdPkgs = std
+
+	for name, classifier := range map[string]PackageClassifier{"path": path, "mod": mod} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, ClassSTD, classifier.Classify(&Package{Name: "machine", Filepath: "/usr/local/lib/tinygo/src/machine"}))
+			assert.Equal(t, ClassSTD, classifier.Classify(&Package{Name: "runtime", Filepath: "/usr/local/lib/tinygo/src/runtime"}))
+			assert.NotEqual(t, ClassSTD, classifier.Classify(&Package{Name: "net/http", Filepath: "/usr/local/go/src/net/http"}))
+		})
+	}
+}
+
+func TestSetStdPkgs(t *testing.T) {
+	r := require.New(t)
+	f := newTestGoFile(nil, &FileInfo{}, &Package{Name: "main"})
+	std := map[string]struct{}{"machine": {}}
+
+	f.SetStdPkgs(std)
+	delete(std, "machine")
+	r.Equal(map[string]struct{}{"machine": {}}, f.stdPkgNames)
+	r.Nil(f.pkgs, "packages should be classified again")
+
+	f.SetStdPkgs(nil)
+	r.Nil(f.stdPkgNames)
+}
+
+func TestUnknownAsMainClassifier(t *testing.T) {
+	path := NewPathPackageClassifier("/home/user/app")
+	classifier := NewUnknownAsMainClassifier(path)
+
+	unknown := &Package{Name: "example.com/lib/db", Filepath: "/opt/src/db"}
+	assert.Equal(t, ClassUnknown, path.Classify(unknown))
+	assert.Equal(t, ClassMain, classifier.Classify(unknown))
+	assert.Equal(t, ClassSTD, classifier.Classify(&Package{Name: "os", Filepath: "/usr/local/go/src/os"}))
+	assert.Equal(t, ClassVendor, classifier.Classify(&Package{Name: "github.com/a/b", Filepath: "/home/user/go/pkg/mod/github.com/a/b@v1.0.0"}))
+}
+
+func TestSetUnknownAsMain(t *testing.T) {
+	r := require.New(t)
+	f := newTestGoFile(nil, &FileInfo{}, &Package{Name: "main"})
+	f.SetUnknownAsMain(true)
+	r.True(f.unknownAsMain)
+	r.Nil(f.pkgs, "packages should be classified again")
+}
+
 func TestModInfoPackageClassification(t *testing.T) {
 	r := require.New(t)
 	a := require.New(t)
@@ -609,3 +761,37 @@ func TestModInfoPackageClassification(t *testing.T) {
 		a.Equal(expected, pkgs[i].Name, fmt.Sprintf("Index %d is incorrect.", i))
 	}
 }
+
+func TestDiffPackages(t *testing.T) {
+	newFile := func(pkgs, vendors []string) *GoFile {
+		f := newTestGoFile(nil, nil)
+		for _, p := range pkgs {
+			f.pkgs = append(f.pkgs, &Package{Name: p})
+		}
+		for _, p := range vendors {
+			f.vendors = append(f.vendors, &Package{Name: p})
+		}
+		return f
+	}
+
+	a := newFile([]string{"main", "main/a", "main/b"}, []string{"github.com/x/y"})
+	b := newFile([]string{"main", "main/c", "main/b"}, []string{"github.com/x/z"})
+
+	t.Run("main packages", func(t *testing.T) {
+		r := require.New(t)
+		onlyA, onlyB, common, err := DiffPackages(a, b, false)
+		r.NoError(err)
+		r.Equal([]string{"main/a"}, onlyA)
+		r.Equal([]string{"main/c"}, onlyB)
+		r.Equal([]string{"main", "main/b"}, common)
+	})
+
+	t.Run("with vendors", func(t *testing.T) {
+		r := require.New(t)
+		onlyA, onlyB, common, err := DiffPackages(a, b, true)
+		r.NoError(err)
+		r.Equal([]string{"github.com/x/y", "main/a"}, onlyA)
+		r.Equal([]string{"github.com/x/z", "main/c"}, onlyB)
+		r.Equal([]string{"main", "main/b"}, common)
+	})
+}
@@ -20,6 +20,10 @@ package gore
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
 )
 
 // keep sync with debug/gosym/pclntab.go
@@ -30,37 +34,569 @@ const (
 	gopclntab120magic uint32 = 0xfffffff1
 )
 
-// searchSectionForTab looks for the PCLN table within the section.
-func searchSectionForTab(secData []byte, order binary.ByteOrder) ([]byte, error) {
+// pclntabVersions maps the magic of the PCLN table to the Go version that
+// introduced the table's format.
+var pclntabVersions = map[uint32]string{
+	gopclntab12magic:  "1.2",
+	gopclntab116magic: "1.16",
+	gopclntab118magic: "1.18",
+	gopclntab120magic: "1.20",
+}
+
+// searchSectionForTab looks for the PCLN table within the section. A match at
+// the start of the section is only accepted if atStart is true. The sections
+// that are searched usually hold other data before the table, so a match at
+// the start is more likely to be a false positive.
+func searchSectionForTab(secData []byte, order binary.ByteOrder, atStart bool) ([]byte, error) {
+	offs := searchSectionForTabs(secData, order, atStart)
+	if len(offs) == 0 {
+		return nil, ErrNoPCLNTab
+	}
+	return secData[offs[0]:], nil
+}
+
+// searchSectionForTabs returns the offsets of all the PCLN table headers
+// within the section, in the order searchSectionForTab prefers them. The
+// headers with the newest magic come first and for each magic, the headers
+// are ordered from the end of the section to the start.
+func searchSectionForTabs(secData []byte, order binary.ByteOrder, atStart bool) []int {
+	var offs []int
 	// First check for the current magic used. If this fails, it could be
 	// an older version. So check for the old header.
-MagicLoop:
 	for _, magic := range []uint32{gopclntab120magic, gopclntab118magic, gopclntab116magic, gopclntab12magic} {
 		bMagic := make([]byte, 6) // 4 bytes for the magic, 2 bytes for padding.
 		order.PutUint32(bMagic, magic)
 
-		off := bytes.LastIndex(secData, bMagic)
-		if off == -1 {
-			continue // Try other magic.
-		}
-		for off != -1 {
-			if off != 0 {
-				buf := secData[off:]
-				if len(buf) < 16 || buf[4] != 0 || buf[5] != 0 ||
-					(buf[6] != 1 && buf[6] != 2 && buf[6] != 4) || // pc quantum
-					(buf[7] != 4 && buf[7] != 8) { // pointer size
-					// Header doesn't match.
-					if off-1 <= 0 {
-						continue MagicLoop
-					}
-					off = bytes.LastIndex(secData[:off-1], bMagic)
-					continue
-				}
-				// Header match
-				return secData[off:], nil
+		for off := bytes.LastIndex(secData, bMagic); off != -1; off = bytes.LastIndex(secData[:off], bMagic) {
+			if off == 0 && !atStart {
+				break
 			}
-			break
+			buf := secData[off:]
+			if len(buf) < 16 || buf[4] != 0 || buf[5] != 0 ||
+				(buf[6] != 1 && buf[6] != 2 && buf[6] != 4) || // pc quantum
+				(buf[7] != 4 && buf[7] != 8) { // pointer size
+				// Header doesn't match.
+				continue
+			}
+			offs = append(offs, off)
+		}
+	}
+	return offs
+}
+
+// pclntabCandidates returns the addresses of the PCLN tables within the
+// section data located at the address. The magic and the header can match by
+// chance, so only the tables with a valid header are returned.
+func pclntabCandidates(addr uint64, secData []byte, order binary.ByteOrder, atStart bool) []uint64 {
+	var addrs []uint64
+	for _, off := range searchSectionForTabs(secData, order, atStart) {
+		if _, err := newPCLNTable(secData[off:], addr+uint64(off), 0, order); err != nil {
+			continue
+		}
+		addrs = append(addrs, addr+uint64(off))
+	}
+	return addrs
+}
+
+// pclnTable gives access to the raw data stored in the PCLN table that is
+// not exposed by the gosym package, for example the function metadata
+// tables used by the runtime.
+type pclnTable struct {
+	// data is the raw PCLN table.
+	data []byte
+	// addr is the virtual address where the table is located.
+	addr uint64
+	// textStart is the address of "runtime.text". Function entries in
+	// tables produced by Go 1.18 and later are offsets from this address.
+	textStart uint64
+
+	order   binary.ByteOrder
+	magic   uint32
+	quantum uint8
+	ptrSize int
+	nfunc   int
+
+	funcnametab []byte
+	cutab       []byte
+	filetab     []byte
+	pctab       []byte
+	// funcdata is the data that the function offsets in the functab are
+	// relative to.
+	funcdata []byte
+	functab  []byte
+
+	// legacyNFuncData is true if the nfuncdata field of the _func structure
+	// is encoded as an int32. This is the case before Go 1.12.
+	legacyNFuncData bool
+}
+
+// newPCLNTable parses the header of the PCLN table.
+func newPCLNTable(data []byte, addr, textStart uint64, order binary.ByteOrder) (*pclnTable, error) {
+	if len(data) < 16 || data[4] != 0 || data[5] != 0 ||
+		(data[6] != 1 && data[6] != 2 && data[6] != 4) || // pc quantum
+		(data[7] != 4 && data[7] != 8) { // pointer size
+		return nil, ErrNoPCLNTab
+	}
+
+	t := &pclnTable{
+		data:      data,
+		addr:      addr,
+		textStart: textStart,
+		order:     order,
+		magic:     order.Uint32(data),
+		quantum:   data[6],
+		ptrSize:   int(data[7]),
+	}
+
+	offset := func(word int) uint64 {
+		return t.uintptr(data[8+word*t.ptrSize:])
+	}
+	sub := func(word int) ([]byte, error) {
+		off := offset(word)
+		if off > uint64(len(data)) {
+			return nil, fmt.Errorf("pclntab header field %d is out of bounds", word)
+		}
+		return data[off:], nil
+	}
+
+	var err error
+	switch t.magic {
+	case gopclntab118magic, gopclntab120magic:
+		t.nfunc = int(offset(0))
+		if t.funcnametab, err = sub(3); err != nil {
+			return nil, err
+		}
+		if t.cutab, err = sub(4); err != nil {
+			return nil, err
+		}
+		if t.filetab, err = sub(5); err != nil {
+			return nil, err
+		}
+		if t.pctab, err = sub(6); err != nil {
+			return nil, err
+		}
+		if t.funcdata, err = sub(7); err != nil {
+			return nil, err
+		}
+		t.functab = t.funcdata
+	case gopclntab116magic:
+		t.nfunc = int(offset(0))
+		if t.funcnametab, err = sub(2); err != nil {
+			return nil, err
+		}
+		if t.cutab, err = sub(3); err != nil {
+			return nil, err
+		}
+		if t.filetab, err = sub(4); err != nil {
+			return nil, err
+		}
+		if t.pctab, err = sub(5); err != nil {
+			return nil, err
+		}
+		if t.funcdata, err = sub(6); err != nil {
+			return nil, err
+		}
+		t.functab = t.funcdata
+	case gopclntab12magic:
+		t.nfunc = int(offset(0))
+		t.funcnametab = data
+		t.pctab = data
+		t.funcdata = data
+		t.functab = data[8+t.ptrSize:]
+	default:
+		return nil, ErrNoPCLNTab
+	}
+
+	if t.magic != gopclntab12magic {
+		// The cutab holds a 4 byte offset into the filetab for each file.
+		if nfiles := offset(1); nfiles <= uint64(len(t.cutab))/4 {
+			t.cutab = t.cutab[:nfiles*4]
+		}
+	}
+
+	functabSize := (t.nfunc*2 + 1) * t.functabFieldSize()
+	if t.nfunc < 0 || functabSize > len(t.functab) {
+		return nil, errors.New("pclntab functab is out of bounds")
+	}
+	t.functab = t.functab[:functabSize]
+
+	return t, nil
+}
+
+// uintptr returns the pointer sized value stored at b.
+func (t *pclnTable) uintptr(b []byte) uint64 {
+	if t.ptrSize == intSize32 {
+		return uint64(t.order.Uint32(b))
+	}
+	return t.order.Uint64(b)
+}
+
+// functabFieldSize returns the size of the fields in the functab.
+func (t *pclnTable) functabFieldSize() int {
+	if t.magic == gopclntab118magic || t.magic == gopclntab120magic {
+		return 4
+	}
+	return t.ptrSize
+}
+
+func (t *pclnTable) functabField(i int) uint64 {
+	sz := t.functabFieldSize()
+	if sz == 4 {
+		return uint64(t.order.Uint32(t.functab[i*sz:]))
+	}
+	return t.order.Uint64(t.functab[i*sz:])
+}
+
+// funcEntry returns the entry address of the i'th function in the functab.
+func (t *pclnTable) funcEntry(i int) uint64 {
+	entry := t.functabField(2 * i)
+	if t.magic == gopclntab118magic || t.magic == gopclntab120magic {
+		entry += t.textStart
+	}
+	return entry
+}
+
+// findFunc returns the index in the functab for the function starting at
+// the given entry address.
+func (t *pclnTable) findFunc(entry uint64) (int, bool) {
+	i := sort.Search(t.nfunc, func(i int) bool {
+		return t.funcEntry(i) >= entry
+	})
+	if i == t.nfunc || t.funcEntry(i) != entry {
+		return 0, false
+	}
+	return i, true
+}
+
+// funcInfo holds the fields of the runtime's _func structure.
+type funcInfo struct {
+	t *pclnTable
+	// data is the raw memory of the structure, starting at the
+	// beginning of the structure.
+	data []byte
+	// addr is the virtual address of the structure.
+	addr uint64
+	// size is the size of the structure, excluding the pcdata and
+	// funcdata arrays that follows it.
+	size int
+
+	entry     uint64
+	nameOff   uint32
+	pcsp      uint32
+	pcfile    uint32
+	pcln      uint32
+	npcdata   uint32
+	cuOffset  uint32
+	nfuncdata uint8
+}
+
+// funcInfo parses the _func structure for the i'th function in the functab.
+func (t *pclnTable) funcInfo(i int) (*funcInfo, error) {
+	off := t.functabField(2*i + 1)
+	if off >= uint64(len(t.funcdata)) {
+		return nil, fmt.Errorf("function data offset 0x%x is out of bounds", off)
+	}
+
+	// The first field is the entry. Before Go 1.18 it was stored as an
+	// address, since then it's a 32-bit offset from runtime.text.
+	sz0 := t.ptrSize
+	if t.magic == gopclntab118magic || t.magic == gopclntab120magic {
+		sz0 = 4
+	}
+	// The rest of the fields are 4 bytes. The last field holds the number
+	// of funcdata entries. Go 1.20 added the startLine field.
+	size := sz0 + 8*4
+	if t.magic == gopclntab116magic || t.magic == gopclntab118magic {
+		size += 4
+	} else if t.magic == gopclntab120magic {
+		size += 2 * 4
+	}
+
+	data := t.funcdata[off:]
+	if len(data) < size {
+		return nil, fmt.Errorf("function data at offset 0x%x is truncated", off)
+	}
+
+	field := func(n int) uint32 {
+		return t.order.Uint32(data[sz0+(n-1)*4:])
+	}
+
+	fi := &funcInfo{
+		t:        t,
+		data:     data,
+		addr:     t.addr + uint64(len(t.data)-len(data)),
+		size:     size,
+		entry:    t.funcEntry(i),
+		nameOff:  field(1),
+		pcsp:     field(4),
+		pcfile:   field(5),
+		pcln:     field(6),
+		npcdata:  field(7),
+		cuOffset: field(8),
+	}
+
+	if t.magic == gopclntab12magic {
+		// Before Go 1.16 there was no cuOffset field.
+		fi.cuOffset = 0
+		if t.legacyNFuncData {
+			fi.nfuncdata = uint8(field(8))
+		} else {
+			fi.nfuncdata = data[size-1]
+		}
+	} else {
+		fi.nfuncdata = data[size-1]
+	}
+
+	return fi, nil
+}
+
+// pcdataOffset returns the offset into the pctab for the i'th pcdata table.
+func (fi *funcInfo) pcdataOffset(i int) (uint32, bool) {
+	if i < 0 || uint32(i) >= fi.npcdata {
+		return 0, false
+	}
+	off := fi.size + i*4
+	if off+4 > len(fi.data) {
+		return 0, false
+	}
+	return fi.t.order.Uint32(fi.data[off:]), true
+}
+
+// funcdataValue returns the raw value stored in the i'th funcdata slot. Before
+// Go 1.18 the value is an address, since then it is an offset from the
+// "go:func.*" symbol.
+func (fi *funcInfo) funcdataValue(i int) (uint64, bool) {
+	if i < 0 || i >= int(fi.nfuncdata) {
+		return 0, false
+	}
+	off := fi.size + int(fi.npcdata)*4
+
+	if fi.t.magic == gopclntab118magic || fi.t.magic == gopclntab120magic {
+		off += i * 4
+		if off+4 > len(fi.data) {
+			return 0, false
+		}
+		return uint64(fi.t.order.Uint32(fi.data[off:])), true
+	}
+
+	// The funcdata array is pointer aligned.
+	if fi.t.ptrSize == intSize64 && (fi.addr+uint64(off))&4 != 0 {
+		off += 4
+	}
+	off += i * fi.t.ptrSize
+	if off+fi.t.ptrSize > len(fi.data) {
+		return 0, false
+	}
+	return fi.t.uintptr(fi.data[off:]), true
+}
+
+// pcvalue returns the value in the pc-value table at off in the pctab for the
+// pc in the function starting at entry.
+func (t *pclnTable) pcvalue(off uint32, entry, pc uint64) (int32, bool) {
+	if off == 0 || uint64(off) >= uint64(len(t.pctab)) {
+		return 0, false
+	}
+	p := t.pctab[off:]
+	val := int32(-1)
+	cur := entry
+	for {
+		uvdelta, n := binary.Uvarint(p)
+		if n <= 0 || (uvdelta == 0 && cur != entry) {
+			return 0, false
+		}
+		p = p[n:]
+		// The value delta is zig-zag encoded.
+		if uvdelta&1 != 0 {
+			uvdelta = ^(uvdelta >> 1)
+		} else {
+			uvdelta >>= 1
+		}
+		val += int32(uvdelta)
+		pcdelta, n := binary.Uvarint(p)
+		if n <= 0 {
+			return 0, false
+		}
+		p = p[n:]
+		cur += pcdelta * uint64(t.quantum)
+		if pc < cur {
+			return val, true
+		}
+	}
+}
+
+// pcvalueTable decodes the pc-value table at off in the pctab for the function
+// starting at entry into the ranges of pcs that share the same value.
+func (t *pclnTable) pcvalueTable(off uint32, entry uint64) ([]PCValue, error) {
+	if off == 0 {
+		return nil, nil
+	}
+	if uint64(off) >= uint64(len(t.pctab)) {
+		return nil, fmt.Errorf("pc-value table offset 0x%x is out of bounds", off)
+	}
+	p := t.pctab[off:]
+	var vals []PCValue
+	val := int32(-1)
+	cur := entry
+	for {
+		uvdelta, n := binary.Uvarint(p)
+		if n <= 0 {
+			return nil, errors.New("pc-value table is truncated")
+		}
+		if uvdelta == 0 && cur != entry {
+			return vals, nil
+		}
+		p = p[n:]
+		// The value delta is zig-zag encoded.
+		if uvdelta&1 != 0 {
+			uvdelta = ^(uvdelta >> 1)
+		} else {
+			uvdelta >>= 1
+		}
+		val += int32(uvdelta)
+		pcdelta, n := binary.Uvarint(p)
+		if n <= 0 {
+			return nil, errors.New("pc-value table is truncated")
+		}
+		p = p[n:]
+		start := cur
+		cur += pcdelta * uint64(t.quantum)
+		vals = append(vals, PCValue{Start: start, End: cur, Value: val})
+	}
+}
+
+// funcFile returns the source file of the function starting at entry. The
+// file number is read from the function's pcfile table and is an index into
+// the compilation unit's part of the cutab, which holds the offset of the
+// name in the filetab. This is only supported for tables produced by Go 1.16
+// and later, for older tables an empty string is returned.
+func (t *pclnTable) funcFile(entry uint64) string {
+	if t.magic != gopclntab116magic && t.magic != gopclntab118magic && t.magic != gopclntab120magic {
+		return ""
+	}
+	i, ok := t.findFunc(entry)
+	if !ok {
+		return ""
+	}
+	fi, err := t.funcInfo(i)
+	if err != nil {
+		return ""
+	}
+	fno, ok := t.pcvalue(fi.pcfile, entry, entry)
+	if !ok || fno < 0 {
+		return ""
+	}
+	idx := (uint64(fi.cuOffset) + uint64(fno)) * 4
+	if idx+4 > uint64(len(t.cutab)) {
+		return ""
+	}
+	nameOff := t.order.Uint32(t.cutab[idx:])
+	// Files that are not used by the compilation unit are marked with -1.
+	if nameOff == math.MaxUint32 || uint64(nameOff) >= uint64(len(t.filetab)) {
+		return ""
+	}
+	name, _, ok := bytes.Cut(t.filetab[nameOff:], []byte{0})
+	if !ok {
+		return ""
+	}
+	return string(name)
+}
+
+// compilationUnits returns the files of each compilation unit in the cutab,
+// ordered by the position of the compilation unit in the table. The units
+// are found from the cuOffset fields of the functions, so a unit without any
+// function is included in the preceding unit. Files that are not used by the
+// unit are not included. For tables produced by Go versions before 1.16,
+// ErrNoCompilationUnits is returned.
+func (t *pclnTable) compilationUnits() ([][]string, error) {
+	if t.magic != gopclntab116magic && t.magic != gopclntab118magic && t.magic != gopclntab120magic {
+		return nil, ErrNoCompilationUnits
+	}
+
+	seen := make(map[uint32]struct{})
+	var starts []uint32
+	for i := 0; i < t.nfunc; i++ {
+		fi, err := t.funcInfo(i)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[fi.cuOffset]; ok {
+			continue
+		}
+		seen[fi.cuOffset] = struct{}{}
+		starts = append(starts, fi.cuOffset)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	nfiles := uint32(len(t.cutab) / 4)
+	units := make([][]string, 0, len(starts))
+	for i, start := range starts {
+		if start >= nfiles {
+			return nil, fmt.Errorf("compilation unit offset %d is out of bounds", start)
+		}
+		end := nfiles
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		var files []string
+		for j := start; j < end; j++ {
+			nameOff := t.order.Uint32(t.cutab[j*4:])
+			// Files that are not used by the compilation unit are marked with -1.
+			if nameOff == math.MaxUint32 || uint64(nameOff) >= uint64(len(t.filetab)) {
+				continue
+			}
+			name, _, ok := bytes.Cut(t.filetab[nameOff:], []byte{0})
+			if !ok {
+				continue
+			}
+			files = append(files, string(name))
+		}
+		units = append(units, files)
+	}
+	return units, nil
+}
+
+// funcNames returns the names of the functions in the functab, in the order
+// of the table. The names are read from the funcnametab, so the _func
+// structures are the only other data parsed.
+func (t *pclnTable) funcNames() ([]string, error) {
+	names := make([]string, 0, t.nfunc)
+	for i := 0; i < t.nfunc; i++ {
+		fi, err := t.funcInfo(i)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(fi.nameOff) >= uint64(len(t.funcnametab)) {
+			return nil, fmt.Errorf("name offset 0x%x for function %d is out of bounds", fi.nameOff, i)
+		}
+		name, _, ok := bytes.Cut(t.funcnametab[fi.nameOff:], []byte{0})
+		if !ok {
+			return nil, fmt.Errorf("name for function %d is not terminated", i)
+		}
+		names = append(names, string(name))
+	}
+	return names, nil
+}
+
+// pcvalueTableSize returns the size of the encoded pc-value table at the
+// start of p. The table is a sequence of value and pc delta pairs that is
+// terminated by a zero value delta.
+func pcvalueTableSize(p []byte) int {
+	n := 0
+	first := true
+	for {
+		vdelta, l := binary.Uvarint(p[n:])
+		if l <= 0 {
+			return n
+		}
+		n += l
+		if vdelta == 0 && !first {
+			return n
+		}
+		_, l = binary.Uvarint(p[n:])
+		if l <= 0 {
+			return n
 		}
+		n += l
+		first = false
 	}
-	return nil, ErrNoPCLNTab
 }
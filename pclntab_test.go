@@ -18,6 +18,9 @@
 package gore
 
 import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
 	"path/filepath"
 	"testing"
 
@@ -47,3 +50,257 @@ func TestGo116PCLNTab(t *testing.T) {
 	}
 
 }
+
+func TestPCLNTabVersion(t *testing.T) {
+	tests := []struct {
+		magic    uint32
+		expected string
+	}{
+		{gopclntab12magic, "1.2"},
+		{gopclntab116magic, "1.16"},
+		{gopclntab118magic, "1.18"},
+		{gopclntab120magic, "1.20"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			r := require.New(t)
+			f := newTestGoFile(nil, &FileInfo{ByteOrder: binary.LittleEndian})
+			f.pclntabBytes = binary.LittleEndian.AppendUint32(nil, test.magic)
+
+			v, err := f.PCLNTabVersion()
+			r.NoError(err)
+			r.Equal(test.expected, v)
+		})
+	}
+
+	t.Run("unknown magic", func(t *testing.T) {
+		f := newTestGoFile(nil, &FileInfo{ByteOrder: binary.LittleEndian})
+		f.pclntabBytes = []byte{0xff, 0xff, 0xff, 0xff}
+
+		_, err := f.PCLNTabVersion()
+		require.ErrorIs(t, err, ErrNoPCLNTab)
+	})
+}
+
+func TestSearchSectionForTab(t *testing.T) {
+	header := make([]byte, 16)
+	binary.LittleEndian.PutUint32(header, gopclntab120magic)
+	header[6] = 1 // pc quantum
+	header[7] = 8 // pointer size
+
+	t.Run("after other data", func(t *testing.T) {
+		r := require.New(t)
+		sect := append([]byte{1, 2, 3, 4}, header...)
+		for _, atStart := range []bool{true, false} {
+			tab, err := searchSectionForTab(sect, binary.LittleEndian, atStart)
+			r.NoError(err)
+			r.Equal(header, tab)
+		}
+	})
+
+	t.Run("at the start", func(t *testing.T) {
+		r := require.New(t)
+		tab, err := searchSectionForTab(header, binary.LittleEndian, true)
+		r.NoError(err)
+		r.Equal(header, tab)
+
+		_, err = searchSectionForTab(header, binary.LittleEndian, false)
+		r.ErrorIs(err, ErrNoPCLNTab)
+	})
+
+	t.Run("multiple tables", func(t *testing.T) {
+		old := bytes.Clone(header)
+		binary.LittleEndian.PutUint32(old, gopclntab118magic)
+		sect := append([]byte{1, 2, 3, 4}, old...)
+		sect = append(append(sect, header...), header...)
+		require.Equal(t, []int{36, 20, 4}, searchSectionForTabs(sect, binary.LittleEndian, false))
+		require.Empty(t, searchSectionForTabs(header[:8], binary.LittleEndian, true))
+	})
+}
+
+func TestPCLNTabCandidateSections(t *testing.T) {
+	newSection := func(name string, typ elf.SectionType, flags elf.SectionFlag) *elf.Section {
+		return &elf.Section{SectionHeader: elf.SectionHeader{Name: name, Type: typ, Flags: flags}}
+	}
+	f := &elf.File{Sections: []*elf.Section{
+		newSection(".text", elf.SHT_PROGBITS, elf.SHF_ALLOC|elf.SHF_EXECINSTR),
+		newSection(".rodata", elf.SHT_PROGBITS, elf.SHF_ALLOC),
+		newSection(".data.rel.ro", elf.SHT_PROGBITS, elf.SHF_ALLOC|elf.SHF_WRITE),
+		newSection(".bss", elf.SHT_NOBITS, elf.SHF_ALLOC|elf.SHF_WRITE),
+		newSection(".comment", elf.SHT_PROGBITS, 0),
+		newSection(".init", elf.SHT_PROGBITS, elf.SHF_ALLOC|elf.SHF_EXECINSTR),
+		newSection(".data", elf.SHT_PROGBITS, elf.SHF_ALLOC|elf.SHF_WRITE),
+	}}
+
+	var names []string
+	for _, s := range pclntabCandidateSections(f) {
+		names = append(names, s.Name)
+	}
+	require.Equal(t, []string{".data.rel.ro", ".rodata", ".data"}, names)
+}
+
+func TestPCLNTablePCValue(t *testing.T) {
+	// The table starts at offset 1 since offset 0 means no table. The value
+	// is 0 for the first 4 bytes and 2 for the next 8 bytes.
+	tab := &pclnTable{quantum: 1, pctab: []byte{0, 2, 4, 4, 8, 0}}
+
+	tests := []struct {
+		pc       uint64
+		expected int32
+		ok       bool
+	}{
+		{0x1000, 0, true},
+		{0x1003, 0, true},
+		{0x1004, 2, true},
+		{0x100b, 2, true},
+		{0x100c, 0, false},
+	}
+	for _, test := range tests {
+		v, ok := tab.pcvalue(1, 0x1000, test.pc)
+		require.Equal(t, test.ok, ok, "pc 0x%x", test.pc)
+		require.Equal(t, test.expected, v, "pc 0x%x", test.pc)
+	}
+
+	_, ok := tab.pcvalue(0, 0x1000, 0x1000)
+	require.False(t, ok, "offset 0 is no table")
+}
+
+func TestPCLNTablePCValueTable(t *testing.T) {
+	r := require.New(t)
+	tab := &pclnTable{quantum: 1, pctab: []byte{0, 2, 4, 4, 8, 0}}
+
+	vals, err := tab.pcvalueTable(1, 0x1000)
+	r.NoError(err)
+	r.Equal([]PCValue{{Start: 0x1000, End: 0x1004, Value: 0}, {Start: 0x1004, End: 0x100c, Value: 2}}, vals)
+
+	vals, err = tab.pcvalueTable(0, 0x1000)
+	r.NoError(err)
+	r.Empty(vals, "offset 0 is no table")
+
+	_, err = (&pclnTable{quantum: 1, pctab: []byte{0, 2, 4, 4}}).pcvalueTable(1, 0x1000)
+	r.Error(err, "truncated table")
+}
+
+func TestPCLNTableFuncFile(t *testing.T) {
+	const textStart = 0x1000
+
+	newTable := func(cutab []uint32) *pclnTable {
+		// The functab has the entry and the offset of the _func structure for
+		// the function followed by the end of the text. The _func structure
+		// follows the functab.
+		funcdata := binary.LittleEndian.AppendUint32(nil, 0)
+		funcdata = binary.LittleEndian.AppendUint32(funcdata, 12)
+		funcdata = binary.LittleEndian.AppendUint32(funcdata, 0x10)
+		// The fields are the entry offset, nameOff, args, deferreturn, pcsp,
+		// pcfile, pcln, npcdata and cuOffset.
+		for _, v := range []uint32{0, 0, 0, 0, 0, 1, 0, 0, 1} {
+			funcdata = binary.LittleEndian.AppendUint32(funcdata, v)
+		}
+		funcdata = append(funcdata, 0, 0, 0, 0)
+
+		t := &pclnTable{
+			textStart: textStart,
+			order:     binary.LittleEndian,
+			magic:     gopclntab118magic,
+			quantum:   1,
+			ptrSize:   8,
+			nfunc:     1,
+			filetab:   []byte("a.go\x00b.go\x00"),
+			// File number 1 for the whole function.
+			pctab:    []byte{0, 4, 0x10, 0},
+			funcdata: funcdata,
+			functab:  funcdata[:12],
+		}
+		for _, v := range cutab {
+			t.cutab = binary.LittleEndian.AppendUint32(t.cutab, v)
+		}
+		return t
+	}
+
+	r := require.New(t)
+	r.Equal("b.go", newTable([]uint32{0, 0xffffffff, 5}).funcFile(textStart))
+	r.Equal("a.go", newTable([]uint32{5, 5, 0}).funcFile(textStart))
+	r.Empty(newTable([]uint32{0, 0, 0xffffffff}).funcFile(textStart), "file not in the compilation unit")
+	r.Empty(newTable([]uint32{0, 0}).funcFile(textStart), "cutab is too short")
+	r.Empty(newTable([]uint32{0, 0, 5}).funcFile(textStart+4), "not the start of a function")
+
+	old := newTable([]uint32{0, 0, 5})
+	old.magic = gopclntab12magic
+	r.Empty(old.funcFile(textStart), "no cutab before Go 1.16")
+}
+
+func TestPCLNTableFuncNames(t *testing.T) {
+	// The functab has two functions followed by the end of the text. The
+	// _func structures follow the functab and only the nameOff field is set.
+	le := binary.LittleEndian
+	var funcdata []byte
+	for _, v := range []uint32{0, 20, 0x10, 60, 0x20} {
+		funcdata = le.AppendUint32(funcdata, v)
+	}
+	for _, nameOff := range []uint32{0, 10} {
+		funcdata = le.AppendUint32(funcdata, 0)
+		funcdata = le.AppendUint32(funcdata, nameOff)
+		funcdata = append(funcdata, make([]byte, 32)...)
+	}
+
+	newTable := func(funcnametab string) *pclnTable {
+		return &pclnTable{
+			order:       le,
+			magic:       gopclntab118magic,
+			ptrSize:     8,
+			nfunc:       2,
+			funcnametab: []byte(funcnametab),
+			funcdata:    funcdata,
+			functab:     funcdata[:20],
+		}
+	}
+
+	r := require.New(t)
+	names, err := newTable("main.main\x00main.init\x00").funcNames()
+	r.NoError(err)
+	r.Equal([]string{"main.main", "main.init"}, names)
+
+	_, err = newTable("main.main\x00").funcNames()
+	r.Error(err, "name offset out of bounds")
+
+	_, err = newTable("main.main\x00main.init").funcNames()
+	r.Error(err, "name not terminated")
+}
+
+func TestPCLNTableCompilationUnits(t *testing.T) {
+	// The functab has two functions followed by the end of the text. The
+	// _func structures follow the functab and only the cuOffset field is set.
+	le := binary.LittleEndian
+	var funcdata []byte
+	for _, v := range []uint32{0, 20, 0x10, 60, 0x20} {
+		funcdata = le.AppendUint32(funcdata, v)
+	}
+	for _, cuOffset := range []uint32{2, 0} {
+		funcdata = append(funcdata, make([]byte, 4+7*4)...)
+		funcdata = le.AppendUint32(funcdata, cuOffset)
+		funcdata = append(funcdata, make([]byte, 4)...)
+	}
+
+	tab := &pclnTable{
+		order:    le,
+		magic:    gopclntab118magic,
+		ptrSize:  8,
+		nfunc:    2,
+		filetab:  []byte("a.go\x00b.go\x00c.go\x00"),
+		funcdata: funcdata,
+		functab:  funcdata[:20],
+	}
+	for _, v := range []uint32{0, 5, 0xffffffff, 10} {
+		tab.cutab = le.AppendUint32(tab.cutab, v)
+	}
+
+	r := require.New(t)
+	units, err := tab.compilationUnits()
+	r.NoError(err)
+	r.Equal([][]string{{"a.go", "b.go"}, {"c.go"}}, units)
+
+	tab.magic = gopclntab12magic
+	_, err = tab.compilationUnits()
+	r.ErrorIs(err, ErrNoCompilationUnits)
+}
@@ -59,6 +59,7 @@ func openPE(r io.ReaderAt) (peF *peFile, err error) {
 
 	peF = &peFile{file: f, reader: r, imageBase: imageBase}
 	peF.getsymtab = sync.OnceValues(peF.initSymTab)
+	peF.getdwarf = sync.OnceValues(f.DWARF)
 	return
 }
 
@@ -69,6 +70,8 @@ type peFile struct {
 	reader    io.ReaderAt
 	imageBase uint64
 	getsymtab func() (map[string]Symbol, error)
+	getdwarf  func() (*dwarf.Data, error)
+	closer    closeGuard
 }
 
 func (p *peFile) initSymTab() (map[string]Symbol, error) {
@@ -120,6 +123,11 @@ func (p *peFile) getSymbol(name string) (Symbol, error) {
 	return sym, nil
 }
 
+func (p *peFile) hasSymbolTable() bool {
+	symm, err := p.getsymtab()
+	return err == nil && len(symm) > 0
+}
+
 func (p *peFile) getParsedFile() any {
 	return p.file
 }
@@ -129,11 +137,13 @@ func (p *peFile) getReader() io.ReaderAt {
 }
 
 func (p *peFile) Close() error {
-	err := p.file.Close()
-	if err != nil {
-		return err
-	}
-	return tryClose(p.reader)
+	return p.closer.close(func() error {
+		err := p.file.Close()
+		if err != nil {
+			return err
+		}
+		return tryClose(p.reader)
+	})
 }
 
 func (p *peFile) getRData() ([]byte, error) {
@@ -167,7 +177,7 @@ func (p *peFile) getPCLNTABData() (uint64, []byte, error) {
 		if err != nil {
 			continue
 		}
-		tab, err := searchSectionForTab(secData, p.getFileInfo().ByteOrder)
+		tab, err := searchSectionForTab(secData, p.getFileInfo().ByteOrder, false)
 		if errors.Is(ErrNoPCLNTab, err) {
 			continue
 		}
@@ -178,6 +188,22 @@ func (p *peFile) getPCLNTABData() (uint64, []byte, error) {
 	return 0, []byte{}, ErrNoPCLNTab
 }
 
+func (p *peFile) getPCLNTABCandidates() []uint64 {
+	var addrs []uint64
+	for _, v := range []string{".rdata", ".text"} {
+		sec := p.file.Section(v)
+		if sec == nil {
+			continue
+		}
+		secData, err := sec.Data()
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, pclntabCandidates(p.imageBase+uint64(sec.VirtualAddress), secData, p.getFileInfo().ByteOrder, false)...)
+	}
+	return addrs
+}
+
 func (p *peFile) getSectionDataFromAddress(address uint64) (uint64, []byte, error) {
 	for _, section := range p.file.Sections {
 		if section.Offset == 0 {
@@ -193,6 +219,43 @@ func (p *peFile) getSectionDataFromAddress(address uint64) (uint64, []byte, erro
 	return 0, nil, ErrSectionDoesNotExist
 }
 
+func (p *peFile) getSectionNameFromAddress(address uint64) (string, error) {
+	for _, section := range p.file.Sections {
+		if section.Offset == 0 {
+			// Only exist in memory
+			continue
+		}
+
+		if p.imageBase+uint64(section.VirtualAddress) <= address && address < p.imageBase+uint64(section.VirtualAddress+section.Size) {
+			return section.Name, nil
+		}
+	}
+	return "", ErrSectionDoesNotExist
+}
+
+func (p *peFile) isExecutableAddress(address uint64) bool {
+	for _, section := range p.file.Sections {
+		if section.Characteristics&(pe.IMAGE_SCN_MEM_EXECUTE|pe.IMAGE_SCN_CNT_CODE) == 0 {
+			continue
+		}
+
+		if p.imageBase+uint64(section.VirtualAddress) <= address && address < p.imageBase+uint64(section.VirtualAddress+section.VirtualSize) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *peFile) getEntryPoint() (uint64, error) {
+	switch hdr := p.file.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		return p.imageBase + uint64(hdr.AddressOfEntryPoint), nil
+	case *pe.OptionalHeader64:
+		return p.imageBase + uint64(hdr.AddressOfEntryPoint), nil
+	}
+	return 0, ErrNoEntryPoint
+}
+
 func (p *peFile) getSectionData(name string) (uint64, []byte, error) {
 	section := p.file.Section(name)
 	if section == nil {
@@ -223,5 +286,5 @@ func (p *peFile) getBuildID() (string, error) {
 }
 
 func (p *peFile) getDwarf() (*dwarf.Data, error) {
-	return p.file.DWARF()
+	return p.getdwarf()
 }
@@ -0,0 +1,281 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"debug/dwarf"
+	"debug/plan9obj"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Plan 9 files store the symbols' values but not their sizes. These are the
+// symbol types with an address in the text or the data segment.
+var plan9SymTypes = map[rune]bool{
+	'T': true,
+	't': true,
+	'D': true,
+	'd': true,
+	'B': true,
+	'b': true,
+}
+
+func openPlan9(r io.ReaderAt) (*plan9File, error) {
+	f, err := plan9obj.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing the Plan 9 file: %w", err)
+	}
+	ret := &plan9File{file: f, reader: r}
+	ret.getsymtab = sync.OnceValues(ret.initSymTab)
+	return ret, nil
+}
+
+var _ fileHandler = (*plan9File)(nil)
+
+type plan9File struct {
+	file      *plan9obj.File
+	reader    io.ReaderAt
+	getsymtab func() (map[string]Symbol, error)
+	closer    closeGuard
+}
+
+func (p *plan9File) initSymTab() (map[string]Symbol, error) {
+	syms, err := p.file.Symbols()
+	if err != nil {
+		return nil, fmt.Errorf("error when getting the symbols: %w", err)
+	}
+	syms = slices.DeleteFunc(syms, func(s plan9obj.Sym) bool {
+		return !plan9SymTypes[s.Type]
+	})
+	if len(syms) == 0 {
+		return nil, ErrSymbolNotFound
+	}
+
+	// The size of a symbol is the distance to the symbol that follows it.
+	addrs := make([]uint64, 0, len(syms))
+	for _, s := range syms {
+		addrs = append(addrs, s.Value)
+	}
+	slices.Sort(addrs)
+
+	symm := make(map[string]Symbol)
+	for _, s := range syms {
+		sym := Symbol{Name: s.Name, Value: s.Value}
+		i := sort.Search(len(addrs), func(i int) bool { return addrs[i] > s.Value })
+		if i < len(addrs) {
+			sym.Size = addrs[i] - s.Value
+		}
+		symm[s.Name] = sym
+	}
+	return symm, nil
+}
+
+func (p *plan9File) getSymbol(name string) (Symbol, error) {
+	symm, err := p.getsymtab()
+	if err != nil {
+		return Symbol{}, err
+	}
+	sym, ok := symm[name]
+	if !ok {
+		return Symbol{}, ErrSymbolNotFound
+	}
+	return sym, nil
+}
+
+func (p *plan9File) hasSymbolTable() bool {
+	symm, err := p.getsymtab()
+	return err == nil && len(symm) > 0
+}
+
+func (p *plan9File) getParsedFile() any {
+	return p.file
+}
+
+func (p *plan9File) getReader() io.ReaderAt {
+	return p.reader
+}
+
+func (p *plan9File) Close() error {
+	return p.closer.close(func() error {
+		err := p.file.Close()
+		if err != nil {
+			return err
+		}
+		return tryClose(p.reader)
+	})
+}
+
+// textAddr returns the address of the text segment. It's loaded right after
+// the header.
+func (p *plan9File) textAddr() uint64 {
+	return p.file.LoadAddress + p.file.HdrSize
+}
+
+// dataAddr returns the address of the data segment. It's loaded at the first
+// page after the text segment. The 64-bit files use 2 MB pages.
+func (p *plan9File) dataAddr() uint64 {
+	round := uint64(0x1000)
+	if p.file.Magic&plan9obj.Magic64 != 0 {
+		round = 0x200000
+	}
+	end := p.textAddr()
+	if text := p.file.Section("text"); text != nil {
+		end += uint64(text.Size)
+	}
+	return (end + round - 1) &^ (round - 1)
+}
+
+// plan9Segment is a segment of the file and the address it's loaded at.
+type plan9Segment struct {
+	section *plan9obj.Section
+	addr    uint64
+}
+
+// segments returns the text and the data segments.
+func (p *plan9File) segments() []plan9Segment {
+	return []plan9Segment{
+		{p.file.Section("text"), p.textAddr()},
+		{p.file.Section("data"), p.dataAddr()},
+	}
+}
+
+func (p *plan9File) getRData() ([]byte, error) {
+	// The read-only data is stored in the text segment.
+	return nil, ErrSectionDoesNotExist
+}
+
+func (p *plan9File) getCodeSection() (uint64, []byte, error) {
+	return p.getSectionData("text")
+}
+
+func (p *plan9File) getPCLNTABData() (uint64, []byte, error) {
+	addr, text, err := p.getCodeSection()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// The table is stored in the text segment. The symbols marking its start
+	// and end are used if they exist, otherwise the segment is searched.
+	start, err := p.getSymbol("runtime.pclntab")
+	if err == nil {
+		end, err := p.getSymbol("runtime.epclntab")
+		if err == nil && addr <= start.Value && start.Value <= end.Value && end.Value <= addr+uint64(len(text)) {
+			return start.Value, text[start.Value-addr : end.Value-addr], nil
+		}
+	}
+
+	tab, err := searchSectionForTab(text, p.getFileInfo().ByteOrder, false)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error when search for pclntab: %w", err)
+	}
+	return addr + uint64(len(text)-len(tab)), tab, nil
+}
+
+func (p *plan9File) getPCLNTABCandidates() []uint64 {
+	addr, text, err := p.getCodeSection()
+	if err != nil {
+		return nil
+	}
+	var addrs []uint64
+	if start, err := p.getSymbol("runtime.pclntab"); err == nil {
+		addrs = append(addrs, start.Value)
+	}
+	return append(addrs, pclntabCandidates(addr, text, p.getFileInfo().ByteOrder, false)...)
+}
+
+func (p *plan9File) moduledataSection() string {
+	return "data"
+}
+
+func (p *plan9File) getSectionDataFromAddress(address uint64) (uint64, []byte, error) {
+	for _, s := range p.segments() {
+		if s.section == nil {
+			continue
+		}
+		if s.addr <= address && address < s.addr+uint64(s.section.Size) {
+			data, err := s.section.Data()
+			return s.addr, data, err
+		}
+	}
+	return 0, nil, ErrSectionDoesNotExist
+}
+
+func (p *plan9File) getSectionNameFromAddress(address uint64) (string, error) {
+	for _, s := range p.segments() {
+		if s.section == nil {
+			continue
+		}
+		if s.addr <= address && address < s.addr+uint64(s.section.Size) {
+			return s.section.Name, nil
+		}
+	}
+	return "", ErrSectionDoesNotExist
+}
+
+func (p *plan9File) isExecutableAddress(address uint64) bool {
+	text := p.file.Section("text")
+	return text != nil && p.textAddr() <= address && address < p.textAddr()+uint64(text.Size)
+}
+
+func (p *plan9File) getEntryPoint() (uint64, error) {
+	return p.file.Entry, nil
+}
+
+func (p *plan9File) getSectionData(name string) (uint64, []byte, error) {
+	for _, s := range p.segments() {
+		if s.section == nil || s.section.Name != name {
+			continue
+		}
+		data, err := s.section.Data()
+		return s.addr, data, err
+	}
+	return 0, nil, ErrSectionDoesNotExist
+}
+
+func (p *plan9File) getFileInfo() *FileInfo {
+	fi := &FileInfo{ByteOrder: binary.LittleEndian, OS: "plan9"}
+	switch p.file.Magic {
+	case plan9obj.Magic386:
+		fi.WordSize = intSize32
+		fi.Arch = Arch386
+	case plan9obj.MagicAMD64:
+		fi.WordSize = intSize64
+		fi.Arch = ArchAMD64
+	case plan9obj.MagicARM:
+		fi.WordSize = intSize32
+		fi.Arch = ArchARM
+	}
+	return fi
+}
+
+func (p *plan9File) getBuildID() (string, error) {
+	_, data, err := p.getCodeSection()
+	if err != nil {
+		return "", fmt.Errorf("failed to get code section: %w", err)
+	}
+	return parseBuildIDFromRaw(data)
+}
+
+func (p *plan9File) getDwarf() (*dwarf.Data, error) {
+	return nil, errors.New("no DWARF data in Plan 9 file")
+}
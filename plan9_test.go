@@ -0,0 +1,119 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"bytes"
+	"debug/plan9obj"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPlan9Sym is a symbol added to the file built by buildTestPlan9.
+type testPlan9Sym struct {
+	name  string
+	typ   byte
+	value uint64
+}
+
+// buildTestPlan9 creates a minimal 64-bit Plan 9 executable with the given
+// text and data segments and symbols.
+func buildTestPlan9(text, data []byte, syms ...testPlan9Sym) []byte {
+	be := binary.BigEndian
+	var symtab []byte
+	for _, s := range syms {
+		symtab = be.AppendUint64(symtab, s.value)
+		symtab = append(append(append(symtab, s.typ|0x80), s.name...), 0)
+	}
+
+	var buf []byte
+	for _, v := range []uint32{plan9obj.MagicAMD64, uint32(len(text)), uint32(len(data)), 0, uint32(len(symtab)), 0, 0, 0} {
+		buf = be.AppendUint32(buf, v)
+	}
+	// The 64-bit entry point.
+	buf = be.AppendUint64(buf, 0x200028)
+	buf = append(buf, text...)
+	buf = append(buf, data...)
+	return append(buf, symtab...)
+}
+
+func TestPlan9File(t *testing.T) {
+	const (
+		textAddr = uint64(0x200028)
+		dataAddr = uint64(0x400000)
+	)
+	text := make([]byte, 0x20)
+	copy(text[0x8:], "pclntab")
+	data := []byte("moduledata")
+
+	f, err := OpenReader(bytes.NewReader(buildTestPlan9(text, data,
+		testPlan9Sym{"runtime.text", 'T', textAddr},
+		testPlan9Sym{"runtime.pclntab", 'D', textAddr + 0x8},
+		testPlan9Sym{"runtime.epclntab", 'D', textAddr + 0xf},
+		testPlan9Sym{"runtime.firstmoduledata", 'D', dataAddr},
+		testPlan9Sym{"proc.go", 'f', 1},
+	)))
+	require.NoError(t, err)
+	defer f.Close()
+
+	assert.Equal(t, &FileInfo{Arch: ArchAMD64, OS: "plan9", ByteOrder: binary.LittleEndian, WordSize: intSize64}, f.FileInfo)
+
+	t.Run("sections", func(t *testing.T) {
+		r := require.New(t)
+		addr, sect, err := f.fh.getCodeSection()
+		r.NoError(err)
+		r.Equal(textAddr, addr)
+		r.Equal(text, sect)
+
+		addr, sect, err = f.fh.getSectionDataFromAddress(dataAddr + 4)
+		r.NoError(err)
+		r.Equal(dataAddr, addr)
+		r.Equal(data, sect)
+
+		name, err := f.fh.getSectionNameFromAddress(textAddr + 4)
+		r.NoError(err)
+		r.Equal("text", name)
+
+		_, _, err = f.fh.getSectionDataFromAddress(textAddr + uint64(len(text)))
+		r.ErrorIs(err, ErrSectionDoesNotExist)
+
+		r.True(f.fh.isExecutableAddress(textAddr))
+		r.False(f.fh.isExecutableAddress(dataAddr))
+	})
+
+	t.Run("symbols", func(t *testing.T) {
+		r := require.New(t)
+		sym, err := f.fh.getSymbol("runtime.text")
+		r.NoError(err)
+		r.Equal(Symbol{Name: "runtime.text", Value: textAddr, Size: 0x8}, sym)
+
+		_, err = f.fh.getSymbol("proc.go")
+		r.ErrorIs(err, ErrSymbolNotFound)
+	})
+
+	t.Run("pclntab", func(t *testing.T) {
+		r := require.New(t)
+		addr, tab, err := f.fh.getPCLNTABData()
+		r.NoError(err)
+		r.Equal(textAddr+0x8, addr)
+		r.Equal([]byte("pclntab"), tab)
+	})
+}
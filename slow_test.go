@@ -19,11 +19,15 @@
 package gore
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -247,6 +251,111 @@ func TestGetTypesFromDynamicBuiltResources(t *testing.T) {
 	})
 }
 
+func TestStructFieldOffsets(t *testing.T) {
+	getMatrix(t, nil, nil, "fieldOffsets", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		typs, err := f.GetTypes()
+		r.NoError(err)
+
+		var stack *GoType
+		for _, typ := range typs {
+			if typ.Kind != reflect.Struct || len(typ.Fields) == 0 {
+				continue
+			}
+			if typ.Name == "runtime.stack" {
+				stack = typ
+			}
+			a.Zero(typ.Fields[0].FieldOffset, "first field of %s", typ.Name)
+			for i := 1; i < len(typ.Fields); i++ {
+				a.GreaterOrEqual(typ.Fields[i].FieldOffset, typ.Fields[i-1].FieldOffset, "field %s of %s", typ.Fields[i].FieldName, typ.Name)
+			}
+		}
+
+		r.NotNil(stack, "the stack type from runtime not found")
+		r.Len(stack.Fields, 2)
+		a.Equal(uint64(f.FileInfo.WordSize), stack.Fields[1].FieldOffset)
+	})
+}
+
+func TestTypesIter(t *testing.T) {
+	getMatrix(t, nil, nil, "typesIter", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		typs, err := f.GetTypes()
+		r.NoError(err)
+
+		seen := make(map[uint64]bool)
+		err = f.TypesIter(func(typ *GoType) bool {
+			a.False(seen[typ.Addr], "type %s yielded more than once", typ)
+			seen[typ.Addr] = true
+			return true
+		})
+		r.NoError(err)
+		a.Len(seen, len(typs))
+
+		// Clearing the cache after each type should not change which
+		// types are yielded.
+		seen = make(map[uint64]bool)
+		err = iterTypes(f.FileInfo, f.fh, f.moduledata, 1, func(typ *GoType) bool {
+			a.False(seen[typ.Addr], "type %s yielded more than once", typ)
+			seen[typ.Addr] = true
+			return true
+		})
+		r.NoError(err)
+		a.Len(seen, len(typs))
+
+		count := 0
+		err = f.TypesIter(func(*GoType) bool {
+			count++
+			return false
+		})
+		r.NoError(err)
+		a.Equal(1, count, "iteration should stop when yield returns false")
+	})
+}
+
+func TestExportedTypeNames(t *testing.T) {
+	getMatrix(t, nil, nil, "exportedTypeNames", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		names, err := f.ExportedTypeNames()
+		r.NoError(err)
+		r.NotEmpty(names)
+		a.True(sort.StringsAreSorted(names), "names should be sorted")
+
+		typs, err := f.GetTypes()
+		r.NoError(err)
+
+		known := make(map[string]bool)
+		for _, typ := range typs {
+			known[typ.Name] = true
+			known[qualifyTypeName(typ.Name, typ.PackagePath)] = true
+		}
+		for i, n := range names {
+			if i > 0 {
+				a.NotEqual(names[i-1], n, "duplicate name")
+			}
+			a.True(known[n], "%s is not a parsed type", n)
+		}
+	})
+}
+
 func TestGetCompilerVersion(t *testing.T) {
 	testVersion := testCompilerVersion()
 	expectedVersion := ResolveGoVersion(testVersion)
@@ -286,6 +395,27 @@ func TestGetBuildID(t *testing.T) {
 	})
 }
 
+func TestHasSymbolTable(t *testing.T) {
+	noStrip := false
+	getMatrix(t, nil, &noStrip, "hasSymbolTable", func(t *testing.T, exe string) {
+		f, err := Open(exe)
+		require.NoError(t, err)
+		defer f.Close()
+		assert.True(t, f.HasSymbolTable())
+	})
+
+	stripped := true
+	getMatrix(t, nil, &stripped, "noSymbolTable", func(t *testing.T, exe string) {
+		f, err := Open(exe)
+		require.NoError(t, err)
+		defer f.Close()
+		// Mach-O files keep the symbols used by the dynamic linker.
+		if f.FileInfo.OS != "macOS" {
+			assert.False(t, f.HasSymbolTable())
+		}
+	})
+}
+
 func TestSourceInfo(t *testing.T) {
 	getMatrix(t, nil, nil, "sourceInfo", func(t *testing.T, exe string) {
 		a := assert.New(t)
@@ -317,6 +447,328 @@ func TestSourceInfo(t *testing.T) {
 		a.NotEqual(0, start)
 		a.NotEqual(0, end)
 		a.NotEqual("", file)
+
+		fastFile, fastStart, err := f.SourceInfoFast(testFn)
+		r.NoError(err)
+		a.Equal(file, fastFile)
+		a.Equal(start, fastStart)
+	})
+}
+
+func TestSymbolizeSourceLines(t *testing.T) {
+	getMatrix(t, nil, nil, "symbolize", func(t *testing.T, exe string) {
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		defer f.Close()
+
+		fns, err := f.Functions()
+		r.NoError(err)
+		r.NotEmpty(fns)
+
+		addrs := make([]uint64, 0, len(fns))
+		for i := len(fns) - 1; i >= 0; i-- {
+			addrs = append(addrs, fns[i].Offset)
+		}
+		syms, err := f.Symbolize(addrs)
+		r.NoError(err)
+		for i, sym := range syms {
+			fn := fns[len(fns)-1-i]
+			r.Same(fn, sym.Function)
+			file, line, err := f.SourceInfoFast(fn)
+			r.NoError(err)
+			r.Equal(file, sym.File)
+			r.Equal(line, sym.Line)
+		}
+	})
+}
+
+func TestFunctions(t *testing.T) {
+	getMatrix(t, nil, nil, "functions", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		fns, err := f.Functions()
+		r.NoError(err)
+		r.NotEmpty(fns)
+
+		a.True(sort.SliceIsSorted(fns, func(i, j int) bool {
+			return fns[i].Offset < fns[j].Offset
+		}), "functions should be sorted by offset")
+
+		mainFn, err := f.MainFunction()
+		r.NoError(err)
+		a.Contains(fns, mainFn)
+	})
+}
+
+func TestFunctionNames(t *testing.T) {
+	getMatrix(t, nil, nil, "function-names", func(t *testing.T, exe string) {
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		defer f.Close()
+
+		names, err := f.FunctionNames()
+		r.NoError(err)
+
+		tab, err := f.PCLNTab()
+		r.NoError(err)
+		expected := make([]string, 0, len(tab.Funcs))
+		for _, fn := range tab.Funcs {
+			expected = append(expected, fn.Name)
+		}
+		r.Equal(expected, names)
+	})
+}
+
+func TestStatsFromDynamicBuiltResources(t *testing.T) {
+	getMatrix(t, nil, nil, "stats", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		s, err := f.Stats()
+		r.NoError(err)
+		r.NoError(s.TypesError)
+
+		fns, err := f.Functions()
+		r.NoError(err)
+		a.Equal(len(fns), s.Functions+s.Methods)
+
+		typs, err := f.GetTypes()
+		r.NoError(err)
+		a.Equal(len(typs), s.Types)
+
+		a.NotZero(s.MainPackages)
+		a.NotZero(s.STDPackages)
+		a.NotZero(s.CodeSize)
+		a.Len(s.LargestFunctions, statsLargestFunctions)
+	})
+}
+
+func TestTextRange(t *testing.T) {
+	getMatrix(t, nil, nil, "textRange", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		start, end, err := f.TextRange()
+		r.NoError(err)
+		r.Less(start, end)
+
+		md, err := f.Moduledata()
+		r.NoError(err)
+		a.Equal(md.Text().Address, start)
+		a.Equal(md.Text().Address+md.Text().Length, end)
+
+		mainFn, err := f.MainFunction()
+		r.NoError(err)
+		a.GreaterOrEqual(mainFn.Offset, start)
+		a.Less(mainFn.Offset, end)
+	})
+}
+
+func TestInitFunctions(t *testing.T) {
+	getMatrix(t, nil, nil, "initFunctions", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		inits, err := f.InitFunctions()
+		r.NoError(err)
+		r.NotEmpty(inits)
+
+		for i, fn := range inits {
+			a.True(fn.Name == "init" || strings.HasPrefix(fn.Name, "init."), "unexpected init function name %s", fn.Name)
+			if i > 0 {
+				a.LessOrEqual(inits[i-1].PackageName, fn.PackageName, "init functions should be sorted by package")
+			}
+		}
+	})
+}
+
+func TestMainFunction(t *testing.T) {
+	getMatrix(t, nil, nil, "mainFunction", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		fn, err := f.MainFunction()
+		r.NoError(err)
+		a.Equal("main", fn.PackageName)
+		a.Equal("main", fn.Name)
+		a.NotZero(fn.Offset)
+	})
+}
+
+func TestSetPCLNTab(t *testing.T) {
+	getMatrix(t, nil, nil, "setPCLNTab", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+
+		// Locate the table with a different handler.
+		ref, err := Open(exe)
+		r.NoError(err)
+		defer ref.Close()
+		r.NoError(ref.initPclntab())
+
+		f, err := Open(exe)
+		r.NoError(err)
+		defer f.Close()
+
+		a.Error(f.SetPCLNTab(ref.pclntabAddr, []byte{0x00, 0x00, 0x00, 0x00}))
+		r.NoError(f.SetPCLNTab(ref.pclntabAddr, ref.pclntabBytes))
+		a.Equal(ref.runtimeText, f.runtimeText)
+
+		fn, err := f.MainFunction()
+		r.NoError(err)
+		a.Equal("main", fn.Name)
+
+		a.ErrorIs(f.SetPCLNTab(ref.pclntabAddr, ref.pclntabBytes), ErrPCLNTabAlreadySet)
+	})
+}
+
+func TestPCLNTabSection(t *testing.T) {
+	getMatrix(t, nil, nil, "pclntabSection", func(t *testing.T, exe string) {
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		section, err := f.PCLNTabSection()
+		r.NoError(err)
+
+		switch f.FileInfo.OS {
+		case "windows":
+			r.Contains([]string{".rdata", ".text"}, section)
+		case "macOS":
+			r.Equal("__gopclntab", section)
+		default:
+			r.Contains([]string{".gopclntab", ".data.rel.ro.gopclntab", ".data.rel.ro"}, section)
+		}
+	})
+}
+
+func TestPCLNTabCandidates(t *testing.T) {
+	getMatrix(t, nil, nil, "pclntabCandidates", func(t *testing.T, exe string) {
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		defer f.Close()
+
+		candidates, err := f.PCLNTabCandidates()
+		r.NoError(err)
+		r.NotEmpty(candidates)
+
+		r.NoError(f.initPclntab())
+		r.Equal(f.pclntabAddr, candidates[0])
+	})
+}
+
+func TestELFPCLNTabInUnknownSection(t *testing.T) {
+	stripped := true
+	getMatrix(t, nil, &stripped, "elfPCLNTabSection", func(t *testing.T, exe string) {
+		r := require.New(t)
+
+		if !strings.Contains(t.Name(), "linux") {
+			t.Skip("only applies to ELF files")
+		}
+
+		buf, err := os.ReadFile(exe)
+		r.NoError(err)
+
+		// Rename the sections so the table has to be searched for.
+		for _, name := range []string{".gopclntab", ".data.rel.ro"} {
+			renamed := strings.Repeat("x", len(name))
+			buf = bytes.ReplaceAll(buf, []byte(name+"\x00"), []byte(renamed+"\x00"))
+		}
+
+		f, err := OpenReader(bytes.NewReader(buf))
+		r.NoError(err)
+		defer f.Close()
+
+		fn, err := f.MainFunction()
+		r.NoError(err)
+		r.Equal("main", fn.Name)
+	})
+}
+
+func TestMachOPCLNTabInDataSection(t *testing.T) {
+	stripped := true
+	getMatrix(t, nil, &stripped, "machoPCLNTabSection", func(t *testing.T, exe string) {
+		r := require.New(t)
+
+		if !strings.Contains(t.Name(), "darwin") {
+			t.Skip("only applies to Mach-O files")
+		}
+
+		buf, err := os.ReadFile(exe)
+		r.NoError(err)
+
+		// Rename the section so the table has to be searched for. The section
+		// name is stored in a fixed size field so it is padded with zeros.
+		buf = bytes.ReplaceAll(buf, []byte("__gopclntab\x00"), []byte("__const\x00\x00\x00\x00\x00"))
+
+		f, err := OpenReader(bytes.NewReader(buf))
+		r.NoError(err)
+		defer f.Close()
+
+		fn, err := f.MainFunction()
+		r.NoError(err)
+		r.Equal("main", fn.Name)
+	})
+}
+
+func TestVersionConsistency(t *testing.T) {
+	getMatrix(t, nil, nil, "versionConsistency", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		consistent, biVer, scanVer, err := f.VersionConsistency()
+		r.NoError(err)
+		a.True(consistent)
+		a.Equal(testCompilerVersion(), biVer)
+		a.Equal(biVer, scanVer)
+	})
+}
+
+func TestSourceFiles(t *testing.T) {
+	getMatrix(t, nil, nil, "sourceFiles", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		files, err := f.SourceFiles()
+		r.NoError(err)
+		r.NotEmpty(files)
+
+		a.True(sort.StringsAreSorted(files), "files should be sorted")
+		a.Contains(files, filepath.ToSlash(filepath.Join(filepath.Dir(exe), "a.go")))
 	})
 }
 
@@ -413,3 +865,467 @@ func testCompilerVersion() string {
 	}
 	return strings.Split(string(out), " ")[2]
 }
+
+func TestInitOrderFromDynamicBuiltResources(t *testing.T) {
+	getMatrix(t, nil, nil, "initOrder", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		ver, err := f.GetCompilerVersion()
+		r.NoError(err)
+
+		fns, err := f.InitOrder()
+		if GoVersionCompare(ver.Name, "go1.21beta1") < 0 {
+			r.ErrorIs(err, ErrNoInitTasks)
+			return
+		}
+		r.NoError(err)
+		r.NotEmpty(fns)
+
+		runtimeIdx, osIdx := -1, -1
+		for i, fn := range fns {
+			switch fn.PackageName + "." + fn.Name {
+			case "runtime.init":
+				if runtimeIdx == -1 {
+					runtimeIdx = i
+				}
+			case "os.init":
+				osIdx = i
+			}
+		}
+		r.NotEqual(-1, runtimeIdx, "runtime.init not found")
+		r.NotEqual(-1, osIdx, "os.init not found")
+		a.Less(runtimeIdx, osIdx)
+	})
+}
+
+func TestAnalyzeFromDynamicBuiltResources(t *testing.T) {
+	getMatrix(t, nil, nil, "analyze", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		res, err := f.Analyze()
+		r.NoError(err)
+		r.Empty(res.Errors)
+		r.NotNil(res.Version)
+		a.NotEmpty(res.Packages)
+		a.NotEmpty(res.STDLib)
+		a.NotEmpty(res.Types)
+		a.NotEmpty(res.GoRoot)
+		a.NotNil(res.Moduledata)
+
+		pkgs, err := f.GetPackages()
+		r.NoError(err)
+		a.Equal(pkgs, res.Packages)
+	})
+}
+
+func TestIsCodeAddressFromDynamicBuiltResources(t *testing.T) {
+	getMatrix(t, nil, nil, "isCodeAddress", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		main, err := f.MainFunction()
+		r.NoError(err)
+		a.True(f.IsCodeAddress(main.Offset))
+
+		md, err := f.Moduledata()
+		r.NoError(err)
+		a.True(f.IsCodeAddress(md.Text().Address))
+		a.False(f.IsCodeAddress(md.Types().Address))
+		a.False(f.IsCodeAddress(md.Data().Address))
+	})
+}
+
+func TestFunctionsByFile(t *testing.T) {
+	getMatrix(t, nil, nil, "functionsByFile", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		pkgs, err := f.GetPackages()
+		r.NoError(err)
+
+		var main *Package
+		for _, p := range pkgs {
+			if p.Name == "main" {
+				main = p
+			}
+		}
+		r.NotNil(main, "the main package not found")
+
+		files := main.FunctionsByFile()
+		var fns []*Function
+		for name, fileFns := range files {
+			if path.Base(name) != "a.go" {
+				continue
+			}
+			fns = fileFns
+		}
+		r.NotEmpty(fns, "no functions for a.go")
+
+		var names []string
+		for i, fn := range fns {
+			names = append(names, fn.Name)
+			if i > 0 {
+				a.Less(fns[i-1].Offset, fn.Offset)
+			}
+		}
+		a.Contains(names, "main")
+		a.Contains(names, "getData")
+	})
+}
+
+func TestFunctionFilename(t *testing.T) {
+	getMatrix(t, nil, nil, "functionFilename", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		main, err := f.MainFunction()
+		r.NoError(err)
+		a.Equal("a.go", path.Base(main.Filename))
+		a.Greater(main.SrcLineStart, 0)
+
+		file, line, _ := f.SourceInfo(main)
+		a.Equal(file, main.Filename)
+		a.GreaterOrEqual(main.SrcLineStart, line)
+
+		// A package without the file names should give the same source files.
+		pkgs, err := f.GetPackages()
+		r.NoError(err)
+		for _, p := range pkgs {
+			noNames := &Package{Name: p.Name}
+			for _, fn := range p.Functions {
+				c := *fn
+				c.Filename = ""
+				noNames.Functions = append(noNames.Functions, &c)
+			}
+			for _, m := range p.Methods {
+				c := *m.Function
+				c.Filename = ""
+				noNames.Methods = append(noNames.Methods, &Method{Receiver: m.Receiver, Function: &c})
+			}
+			a.Equal(f.GetSourceFiles(p), f.GetSourceFiles(noNames), "package %s", p.Name)
+		}
+
+		// The file read from the cutab should match the one from the PCLN table.
+		raw, err := f.getPCLNTable()
+		r.NoError(err)
+		tab, err := f.PCLNTab()
+		r.NoError(err)
+		for _, fn := range tab.Funcs {
+			file, _, _ := tab.PCToLine(fn.Entry)
+			a.Equal(file, raw.funcFile(fn.Entry), "function %s", fn.Name)
+		}
+	})
+}
+
+func TestConcurrentGetters(t *testing.T) {
+	getMatrix(t, nil, nil, "concurrentGetters", func(t *testing.T, exe string) {
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		// Force the compiler version to be extracted lazily.
+		f.FileInfo.goversion = nil
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = f.GetCompilerVersion()
+				_, _ = f.GetPackages()
+				_, _ = f.GetTypes()
+				_, _ = f.GetGoRoot()
+				_, _ = f.Moduledata()
+				_, _ = f.Functions()
+				_, _, _ = f.TextRange()
+			}()
+		}
+		wg.Wait()
+
+		ver, err := f.GetCompilerVersion()
+		r.NoError(err)
+		r.NotNil(ver)
+	})
+}
+
+func TestTypesFromModule(t *testing.T) {
+	getMatrix(t, nil, nil, "typesFromModule", func(t *testing.T, exe string) {
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		md, err := f.Moduledata()
+		r.NoError(err)
+
+		typs, err := f.TypesFromModule(md)
+		r.NoError(err)
+		r.NotEmpty(typs)
+
+		expected, err := f.GetTypes()
+		r.NoError(err)
+		addrs := func(typs []*GoType) []uint64 {
+			var a []uint64
+			for _, typ := range typs {
+				a = append(a, typ.Addr)
+			}
+			return a
+		}
+		r.ElementsMatch(addrs(expected), addrs(typs))
+	})
+}
+
+func TestFileInfoOSAndArch(t *testing.T) {
+	getMatrix(t, nil, nil, "fileInfo", func(t *testing.T, exe string) {
+		a := assert.New(t)
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		r.NotNil(f)
+		defer f.Close()
+
+		switch {
+		case strings.Contains(t.Name(), "linux"):
+			a.Equal("linux", f.FileInfo.OS)
+		case strings.Contains(t.Name(), "darwin"):
+			a.Equal("macOS", f.FileInfo.OS)
+		case strings.Contains(t.Name(), "windows"):
+			a.Equal("windows", f.FileInfo.OS)
+		}
+
+		switch {
+		case strings.Contains(t.Name(), "amd64"):
+			a.Equal(ArchAMD64, f.FileInfo.Arch)
+		case strings.Contains(t.Name(), "arm64"):
+			a.Equal(ArchARM64, f.FileInfo.Arch)
+		case strings.Contains(t.Name(), "386"):
+			a.Equal(Arch386, f.FileInfo.Arch)
+		}
+	})
+}
+
+func TestLinkMode(t *testing.T) {
+	getMatrix(t, nil, nil, "linkMode", func(t *testing.T, exe string) {
+		r := require.New(t)
+		f, err := Open(exe)
+		r.NoError(err)
+		defer f.Close()
+
+		lm, err := f.LinkMode()
+		r.NoError(err)
+		r.Equal(LinkModeInternal, lm)
+	})
+
+	t.Run("external", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("only tested on Linux")
+		}
+		if _, err := exec.LookPath("gcc"); err != nil {
+			t.Skip("no C compiler found")
+		}
+		goBin, err := exec.LookPath("go")
+		require.NoError(t, err)
+
+		for _, mode := range []string{"exe", "pie"} {
+			mode := mode
+			t.Run(mode, func(t *testing.T) {
+				r := require.New(t)
+
+				tmpdir := t.TempDir()
+				src := filepath.Join(tmpdir, "a.go")
+				r.NoError(os.WriteFile(src, []byte(testresourcesrc), 0644))
+
+				exe := filepath.Join(tmpdir, "a")
+				cmd := exec.Command(goBin, "build", "-buildmode="+mode, "-ldflags=-linkmode=external", "-o", exe, src)
+				cmd.Dir = tmpdir
+				cmd.Env = append(cmd.Env, "GOCACHE="+filepath.Join(tmpdir, "cache"), "CGO_ENABLED=1", "GOPATH="+tmpdir, "GOTMPDIR="+tmpdir, "PATH="+os.Getenv("PATH"))
+				out, err := cmd.CombinedOutput()
+				r.NoError(err, string(out))
+
+				f, err := Open(exe)
+				r.NoError(err)
+				defer f.Close()
+
+				lm, err := f.LinkMode()
+				r.NoError(err)
+				r.Equal(LinkModeExternal, lm)
+			})
+		}
+	})
+}
+
+const embedResourceSrc = `
+package main
+
+import (
+	"embed"
+	"fmt"
+)
+
+//go:embed static
+var static embed.FS
+
+func main() {
+	data, _ := static.ReadFile("static/hello.txt")
+	fmt.Println(string(data))
+}
+`
+
+func TestEmbeddedFilesFromBuiltResource(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	require.NoError(t, err)
+
+	for _, target := range []struct{ os, arch string }{
+		{"linux", "amd64"},
+		{"linux", "386"},
+		{"darwin", "arm64"},
+		{"windows", "amd64"},
+	} {
+		target := target
+		t.Run(target.os+"-"+target.arch, func(t *testing.T) {
+			t.Parallel()
+			r := require.New(t)
+
+			tmpdir := t.TempDir()
+			r.NoError(os.Mkdir(filepath.Join(tmpdir, "static"), 0755))
+			r.NoError(os.WriteFile(filepath.Join(tmpdir, "static", "hello.txt"), []byte("Hello, GoRE!"), 0644))
+			r.NoError(os.WriteFile(filepath.Join(tmpdir, "static", "index.html"), []byte("<html></html>"), 0644))
+			src := filepath.Join(tmpdir, "a.go")
+			r.NoError(os.WriteFile(src, []byte(embedResourceSrc), 0644))
+
+			exe := filepath.Join(tmpdir, "a")
+			cmd := exec.Command(goBin, "build", "-o", exe, src)
+			cmd.Dir = tmpdir
+			cmd.Env = append(cmd.Env, "GOCACHE="+filepath.Join(tmpdir, "cache"), "GOARCH="+target.arch, "GOOS="+target.os, "GOPATH="+tmpdir, "GOTMPDIR="+tmpdir, "PATH="+os.Getenv("PATH"))
+			out, err := cmd.CombinedOutput()
+			r.NoError(err, string(out))
+
+			f, err := Open(exe)
+			r.NoError(err)
+			defer f.Close()
+
+			files, err := f.EmbeddedFiles()
+			r.NoError(err)
+			r.Equal([]EmbeddedFile{
+				{Name: "static/"},
+				{Name: "static/hello.txt", Data: []byte("Hello, GoRE!")},
+				{Name: "static/index.html", Data: []byte("<html></html>")},
+			}, files)
+		})
+	}
+}
+
+const coreResourceSrc = `
+package main
+
+func main() {
+	panic("crash")
+}
+`
+
+func TestOpenCoreFromCrash(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("core dumps are only supported on Linux")
+	}
+	pattern, err := os.ReadFile("/proc/sys/kernel/core_pattern")
+	if err != nil || strings.HasPrefix(string(pattern), "|") || strings.Contains(string(pattern), "/") {
+		t.Skip("core dumps are not written to the working directory")
+	}
+	goBin, err := exec.LookPath("go")
+	require.NoError(t, err)
+
+	for _, mode := range []string{"exe", "pie"} {
+		mode := mode
+		t.Run(mode, func(t *testing.T) {
+			r := require.New(t)
+
+			tmpdir := t.TempDir()
+			src := filepath.Join(tmpdir, "a.go")
+			r.NoError(os.WriteFile(src, []byte(coreResourceSrc), 0644))
+
+			exe := filepath.Join(tmpdir, "a")
+			cmd := exec.Command(goBin, "build", "-buildmode="+mode, "-o", exe, src)
+			cmd.Dir = tmpdir
+			cmd.Env = append(cmd.Env, "GOCACHE="+filepath.Join(tmpdir, "cache"), "GOOS=linux", "GOPATH="+tmpdir, "GOTMPDIR="+tmpdir, "PATH="+os.Getenv("PATH"))
+			out, err := cmd.CombinedOutput()
+			r.NoError(err, string(out))
+
+			// The crash is expected to fail the command.
+			cmd = exec.Command("sh", "-c", "ulimit -c unlimited; exec "+exe)
+			cmd.Dir = tmpdir
+			cmd.Env = append(cmd.Env, "GOTRACEBACK=crash")
+			_ = cmd.Run()
+
+			cores, _ := filepath.Glob(filepath.Join(tmpdir, "core*"))
+			if len(cores) == 0 {
+				t.Skip("no core dump was written")
+			}
+
+			f, err := Open(exe)
+			r.NoError(err)
+			defer f.Close()
+			cf, err := OpenCore(cores[0], exe)
+			r.NoError(err)
+			defer cf.Close()
+
+			bias := cf.fh.(*elfCoreFile).bias
+			if mode == "pie" {
+				r.NotZero(bias, "the executable should be relocated")
+			} else {
+				r.Zero(bias)
+			}
+
+			// The entry point is relocated with the rest of the executable.
+			lm, err := cf.LinkMode()
+			r.NoError(err)
+			r.Equal(LinkModeInternal, lm)
+
+			ver, err := f.GetCompilerVersion()
+			r.NoError(err)
+			coreVer, err := cf.GetCompilerVersion()
+			r.NoError(err)
+			r.Equal(ver.Name, coreVer.Name)
+
+			md, err := f.Moduledata()
+			r.NoError(err)
+			coreMd, err := cf.Moduledata()
+			r.NoError(err)
+			r.Equal(md.Text().Address+bias, coreMd.Text().Address)
+
+			mainFn, err := f.MainFunction()
+			r.NoError(err)
+			coreMainFn, err := cf.MainFunction()
+			r.NoError(err)
+			r.Equal(mainFn.Offset+bias, coreMainFn.Offset)
+
+			typs, err := cf.GetTypes()
+			r.NoError(err)
+			r.NotEmpty(typs)
+		})
+	}
+}
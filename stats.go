@@ -0,0 +1,149 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// statsLargestFunctions is the number of functions included in
+// Stats.LargestFunctions.
+const statsLargestFunctions = 10
+
+// Stats is a summary of the content of a Go binary.
+type Stats struct {
+	// Functions is the number of functions, excluding methods.
+	Functions int `json:"functions"`
+	// Methods is the number of methods.
+	Methods int `json:"methods"`
+	// Types is the number of types. If the types could not be parsed, it's the
+	// number of types parsed before the error, see TypesError.
+	Types int `json:"types"`
+	// CodeSize is the number of bytes of code in the functions and methods.
+	CodeSize uint64 `json:"codeSize"`
+	// MainPackages is the number of packages classified as part of the main project.
+	MainPackages int `json:"mainPackages"`
+	// VendorPackages is the number of third party packages.
+	VendorPackages int `json:"vendorPackages"`
+	// STDPackages is the number of standard library packages.
+	STDPackages int `json:"stdPackages"`
+	// GeneratedPackages is the number of compiler generated packages.
+	GeneratedPackages int `json:"generatedPackages"`
+	// UnknownPackages is the number of packages that could not be classified.
+	UnknownPackages int `json:"unknownPackages"`
+	// LargestFunctions are the largest functions and methods by size, largest first.
+	LargestFunctions []StatsFunction `json:"largestFunctions"`
+	// TypesError is the error returned when the types were parsed, or nil if
+	// all the types were parsed. The other statistics are still collected if the
+	// types can't be parsed.
+	TypesError error `json:"-"`
+}
+
+// StatsFunction is a function or a method in Stats.LargestFunctions.
+type StatsFunction struct {
+	*Function
+	// Receiver is the method's receiver. It's empty for functions.
+	Receiver string `json:"receiver,omitempty"`
+}
+
+// String returns the name of the function, including the package and, for
+// methods, the receiver.
+func (s StatsFunction) String() string {
+	name := s.Name
+	if s.Receiver != "" {
+		name = s.Receiver + "." + name
+	}
+	if s.PackageName != "" {
+		name = s.PackageName + "." + name
+	}
+	return name
+}
+
+// String returns a multi-line summary of the statistics.
+func (s Stats) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Functions: %d\n", s.Functions)
+	fmt.Fprintf(&b, "Methods: %d\n", s.Methods)
+	fmt.Fprintf(&b, "Types: %d\n", s.Types)
+	fmt.Fprintf(&b, "Code size: %d bytes\n", s.CodeSize)
+	fmt.Fprintf(&b, "Packages: %d main, %d vendor, %d std, %d generated, %d unknown\n",
+		s.MainPackages, s.VendorPackages, s.STDPackages, s.GeneratedPackages, s.UnknownPackages)
+	b.WriteString("Largest functions:")
+	for _, fn := range s.LargestFunctions {
+		fmt.Fprintf(&b, "\n\t%s: %d bytes", fn, fn.End-fn.Offset)
+	}
+	if s.TypesError != nil {
+		fmt.Fprintf(&b, "\nTypes error: %s", s.TypesError)
+	}
+	return b.String()
+}
+
+// Stats returns a summary of the binary, like the number of functions, types and
+// packages and the largest functions. An error is only returned if the packages
+// can't be parsed. If the types can't be parsed, the error is stored in the
+// summary's TypesError instead.
+func (f *GoFile) Stats() (Stats, error) {
+	pkgs, err := f.allPackages()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	s := Stats{
+		MainPackages:      len(f.pkgs),
+		VendorPackages:    len(f.vendors),
+		STDPackages:       len(f.stdPkgs),
+		GeneratedPackages: len(f.generated),
+		UnknownPackages:   len(f.unknown),
+	}
+
+	var fns []StatsFunction
+	for _, p := range pkgs {
+		s.Functions += len(p.Functions)
+		s.Methods += len(p.Methods)
+		for _, fn := range p.Functions {
+			fns = append(fns, StatsFunction{Function: fn})
+		}
+		for _, m := range p.Methods {
+			fns = append(fns, StatsFunction{Function: m.Function, Receiver: m.Receiver})
+		}
+	}
+	for _, fn := range fns {
+		s.CodeSize += fn.End - fn.Offset
+	}
+
+	sort.Slice(fns, func(i, j int) bool {
+		si, sj := fns[i].End-fns[i].Offset, fns[j].End-fns[j].Offset
+		if si != sj {
+			return si > sj
+		}
+		return fns[i].Offset < fns[j].Offset
+	})
+	if len(fns) > statsLargestFunctions {
+		fns = fns[:statsLargestFunctions]
+	}
+	s.LargestFunctions = fns
+
+	s.TypesError = f.TypesIter(func(*GoType) bool {
+		s.Types++
+		return true
+	})
+
+	return s, nil
+}
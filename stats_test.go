@@ -0,0 +1,91 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	fi := &FileInfo{ByteOrder: binary.LittleEndian, WordSize: 8, goversion: ResolveGoVersion("go1.22.0")}
+	f := newTestGoFile(nil, fi)
+	// The moduledata has no types.
+	f.moduledata.fh = &mockFileHandler{
+		mGetSectionDataFromAddress: func(uint64) (uint64, []byte, error) {
+			return 0, []byte{}, nil
+		},
+		mGetFileInfo: func() *FileInfo { return fi },
+	}
+
+	var mainFns []*Function
+	for i := uint64(0); i < 12; i++ {
+		mainFns = append(mainFns, &Function{Name: fmt.Sprintf("f%d", i), PackageName: "main", Offset: 0x1000 + i*0x100, End: 0x1000 + i*0x100 + i + 1})
+	}
+	write := &Method{Receiver: "(*File)", Function: &Function{Name: "Write", PackageName: "os", Offset: 0x100, End: 0x200}}
+	open := &Function{Name: "Open", PackageName: "os", Offset: 0x200, End: 0x280}
+	f.pkgs = []*Package{{Name: "main", Functions: mainFns}}
+	f.stdPkgs = []*Package{{Name: "os", Functions: []*Function{open}, Methods: []*Method{write}}}
+	f.vendors = []*Package{{Name: "github.com/a/b"}, {Name: "github.com/a/c"}}
+
+	s, err := f.Stats()
+	require.NoError(t, err)
+	assert.NoError(t, s.TypesError)
+	assert.Equal(t, 13, s.Functions)
+	assert.Equal(t, 1, s.Methods)
+	assert.Equal(t, 0, s.Types)
+	assert.Equal(t, uint64(78+0x100+0x80), s.CodeSize)
+	assert.Equal(t, 1, s.MainPackages)
+	assert.Equal(t, 2, s.VendorPackages)
+	assert.Equal(t, 1, s.STDPackages)
+	assert.Equal(t, 0, s.GeneratedPackages)
+	assert.Equal(t, 0, s.UnknownPackages)
+
+	require.Len(t, s.LargestFunctions, statsLargestFunctions)
+	assert.Equal(t, StatsFunction{Function: write.Function, Receiver: "(*File)"}, s.LargestFunctions[0])
+	assert.Equal(t, StatsFunction{Function: open}, s.LargestFunctions[1])
+	assert.Equal(t, mainFns[11], s.LargestFunctions[2].Function)
+	assert.Equal(t, mainFns[4], s.LargestFunctions[9].Function)
+
+	assert.Contains(t, s.String(), "Functions: 13\n")
+	assert.Contains(t, s.String(), "\n\tos.(*File).Write: 256 bytes")
+	assert.Contains(t, s.String(), "\n\tos.Open: 128 bytes")
+	assert.NotContains(t, s.String(), "Types error")
+
+	t.Run("type error", func(t *testing.T) {
+		f.moduledata.fh = &mockFileHandler{
+			mGetSectionDataFromAddress: func(uint64) (uint64, []byte, error) {
+				return 0, nil, ErrSectionDoesNotExist
+			},
+			mGetFileInfo: func() *FileInfo { return fi },
+		}
+
+		s, err := f.Stats()
+		require.NoError(t, err)
+		assert.ErrorIs(t, s.TypesError, ErrSectionDoesNotExist)
+		assert.Equal(t, 13, s.Functions)
+		assert.Equal(t, 1, s.Methods)
+		assert.Equal(t, 1, s.MainPackages)
+		assert.Len(t, s.LargestFunctions, statsLargestFunctions)
+		assert.Contains(t, s.String(), "\nTypes error: ")
+	})
+}
@@ -0,0 +1,104 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"errors"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// rodataSections are the names of the sections that hold the read-only data
+// in the different file formats.
+var rodataSections = []string{".rodata", ".rdata", "__rodata"}
+
+// StringRef is a string found in the read-only data of the binary.
+type StringRef struct {
+	// Address is the virtual address of the first byte of the string.
+	Address uint64
+	// Value is the string.
+	Value string
+}
+
+// Strings returns the runs of printable UTF-8 characters that are at least
+// minLen bytes long in the read-only data sections of the binary, sorted by
+// their address. Unlike strings(1), the address of each string is known, so
+// it can be matched with the addresses loaded by the functions, for example
+// with XRefs. The Go compiler stores the string literals back to back without
+// a terminator, so a run may hold more than one string literal. If the binary
+// has no read-only data section, ErrSectionDoesNotExist is returned.
+func (f *GoFile) Strings(minLen int) ([]StringRef, error) {
+	if minLen < 1 {
+		minLen = 1
+	}
+
+	var refs []StringRef
+	var found bool
+	for _, name := range rodataSections {
+		base, data, err := f.fh.getSectionData(name)
+		if errors.Is(err, ErrSectionDoesNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		refs = append(refs, findStrings(base, data, minLen)...)
+	}
+	if !found {
+		return nil, ErrSectionDoesNotExist
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Address < refs[j].Address
+	})
+	return refs, nil
+}
+
+// findStrings returns the runs of printable UTF-8 characters in the data that
+// are at least minLen bytes long. The base is the address of the data.
+func findStrings(base uint64, data []byte, minLen int) []StringRef {
+	var refs []StringRef
+	start := -1
+	flush := func(end int) {
+		if start >= 0 && end-start >= minLen {
+			refs = append(refs, StringRef{Address: base + uint64(start), Value: string(data[start:end])})
+		}
+		start = -1
+	}
+
+	for i := 0; i < len(data); {
+		r, size := utf8.DecodeRune(data[i:])
+		if (r == utf8.RuneError && size <= 1) || !isStringRune(r) {
+			flush(i)
+			i += max(size, 1)
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		i += size
+	}
+	flush(len(data))
+	return refs
+}
+
+// isStringRune returns true if the rune is expected in a string literal.
+func isStringRune(r rune) bool {
+	return unicode.IsPrint(r) || r == '\t' || r == '\n' || r == '\r'
+}
@@ -0,0 +1,60 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrings(t *testing.T) {
+	data := []byte("\x00\x01hello world\xff\x00ab\x00gopherä\x10")
+	fh := &mockFileHandler{
+		mGetSectionData: func(name string) (uint64, []byte, error) {
+			if name != ".rodata" {
+				return 0, nil, ErrSectionDoesNotExist
+			}
+			return 0x1000, data, nil
+		},
+	}
+	f := &GoFile{fh: fh}
+
+	refs, err := f.Strings(4)
+	require.NoError(t, err)
+	assert.Equal(t, []StringRef{
+		{Address: 0x1002, Value: "hello world"},
+		{Address: 0x1012, Value: "gopherä"},
+	}, refs)
+
+	refs, err = f.Strings(2)
+	require.NoError(t, err)
+	assert.Len(t, refs, 3)
+	assert.Equal(t, StringRef{Address: 0x100f, Value: "ab"}, refs[1])
+}
+
+func TestStringsNoSection(t *testing.T) {
+	fh := &mockFileHandler{
+		mGetSectionData: func(string) (uint64, []byte, error) {
+			return 0, nil, ErrSectionDoesNotExist
+		},
+	}
+	_, err := (&GoFile{fh: fh}).Strings(4)
+	assert.ErrorIs(t, err, ErrSectionDoesNotExist)
+}
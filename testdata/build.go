@@ -23,6 +23,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 )
 
 func main() {
@@ -58,6 +59,15 @@ func main() {
 		return
 	}
 
+	// Externally linked macOS binaries need the system linker so they can
+	// only be built on macOS.
+	if runtime.GOOS == "darwin" {
+		buildDarwinExternal(buildDir, goldFolder)
+	}
+
+	// Windows cgo binaries need a MinGW cross compiler.
+	buildWindowsCgo(goldFolder)
+
 	// Enumerate missing golden binaries.
 	var missing []goversionEntry
 	for _, v := range spec {
@@ -114,6 +124,90 @@ func main() {
 	}
 }
 
+// darwinExtFile is the name of the externally linked macOS binary.
+const darwinExtFile = "darwin-ext"
+
+// buildDarwinExternal builds the golden binary linked with the system linker
+// using the local Go installation. The DWARF data is kept since the linker
+// places it in the "__DWARF" segment.
+func buildDarwinExternal(buildDir, goldFolder string) {
+	if _, err := os.Stat(filepath.Join(goldFolder, darwinExtFile)); err == nil {
+		return
+	}
+	cmd := exec.Command("go", "build", "-ldflags", "-linkmode=external", "-o", filepath.Join(buildDir, darwinExtFile))
+	cmd.Dir = buildDir
+	fmt.Println("Try to build:", darwinExtFile)
+	stderr := bytes.Buffer{}
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Println("Execution failed:", err)
+		fmt.Println("ERR:", stderr.String())
+		return
+	}
+	fmt.Println("Successfuly built:", darwinExtFile)
+
+	err := os.Rename(filepath.Join(buildDir, darwinExtFile), filepath.Join(goldFolder, darwinExtFile))
+	if err != nil {
+		fmt.Printf("Error when moving %s to golden folder: %s.\n", darwinExtFile, err)
+	}
+}
+
+// windowsCgoFile is the name of the Windows cgo binary.
+const windowsCgoFile = "windows-cgo"
+
+// windowsCgoCompiler is the MinGW compiler used to build the Windows cgo
+// binary.
+const windowsCgoCompiler = "x86_64-w64-mingw32-gcc"
+
+// buildWindowsCgo builds the windows/amd64 golden binary that uses cgo. The
+// binary is linked by the C toolchain which places C code before the Go code
+// in the text section. The symbols are stripped so "runtime.text" has to be
+// recovered from the moduledata.
+func buildWindowsCgo(goldFolder string) {
+	if _, err := os.Stat(filepath.Join(goldFolder, windowsCgoFile)); err == nil {
+		return
+	}
+	if _, err := exec.LookPath(windowsCgoCompiler); err != nil {
+		fmt.Println("Skipping", windowsCgoFile+":", windowsCgoCompiler, "not found")
+		return
+	}
+	buildDir, err := os.MkdirTemp("", "gold-cgo-*")
+	if err != nil {
+		fmt.Println("Failed to create a build folder:", err)
+		return
+	}
+	defer os.RemoveAll(buildDir)
+
+	err = os.WriteFile(filepath.Join(buildDir, "target.go"), []byte(cgofile), 0644)
+	if err != nil {
+		fmt.Printf("Error when writing template file to build folder: %s.\n", err)
+		return
+	}
+	err = os.WriteFile(filepath.Join(buildDir, "go.mod"), []byte(gomodstub), 0644)
+	if err != nil {
+		fmt.Printf("Error when writing go.mod file to build folder: %s.\n", err)
+		return
+	}
+
+	cmd := exec.Command("go", "build", "-ldflags", "-s -w -linkmode=external", "-o", filepath.Join(buildDir, windowsCgoFile))
+	cmd.Dir = buildDir
+	cmd.Env = append(os.Environ(), "GOOS=windows", "GOARCH=amd64", "CGO_ENABLED=1", "CC="+windowsCgoCompiler)
+	fmt.Println("Try to build:", windowsCgoFile)
+	stderr := bytes.Buffer{}
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Println("Execution failed:", err)
+		fmt.Println("ERR:", stderr.String())
+		return
+	}
+	fmt.Println("Successfuly built:", windowsCgoFile)
+
+	err = os.Rename(filepath.Join(buildDir, windowsCgoFile), filepath.Join(goldFolder, windowsCgoFile))
+	if err != nil {
+		fmt.Printf("Error when moving %s to golden folder: %s.\n", windowsCgoFile, err)
+	}
+}
+
 type goos string
 type goarch string
 
@@ -121,6 +215,7 @@ const (
 	linux   goos   = "linux"
 	darwin  goos   = "darwin"
 	windows goos   = "windows"
+	freebsd goos   = "freebsd"
 	x86     goarch = "386"
 	amd64   goarch = "amd64"
 	arm64   goarch = "arm64"
@@ -163,7 +258,7 @@ var spec = []struct {
 	{"1.19.0", []osarchTuple{{linux, []goarch{x86, amd64}}, {darwin, []goarch{arm64, amd64}}, {windows, []goarch{x86, amd64}}}},
 	{"1.20.0", []osarchTuple{{linux, []goarch{x86, amd64}}, {darwin, []goarch{arm64, amd64}}, {windows, []goarch{x86, amd64}}}},
 	{"1.21.0", []osarchTuple{{linux, []goarch{x86, amd64}}, {darwin, []goarch{arm64, amd64}}, {windows, []goarch{x86, amd64}}}},
-	{"1.22.0", []osarchTuple{{linux, []goarch{x86, amd64}}, {darwin, []goarch{arm64, amd64}}, {windows, []goarch{x86, amd64}}}},
+	{"1.22.0", []osarchTuple{{linux, []goarch{x86, amd64}}, {darwin, []goarch{arm64, amd64}}, {windows, []goarch{x86, amd64}}, {freebsd, []goarch{amd64}}}},
 }
 
 const gofile = `package main
@@ -200,6 +295,18 @@ func main() {
 	fmt.Printf("Person: %v and a struct %v\n", myPerson, complexStruct)
 }
 `
+const cgofile = `package main
+
+// int add(int a, int b) { return a + b; }
+import "C"
+
+import "fmt"
+
+func main() {
+	fmt.Println("Sum:", C.add(1, 2))
+}
+`
+
 const gomodstub = `module github.com/goretk/gore/gold
 
 go 1.14
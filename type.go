@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 const (
@@ -60,25 +62,256 @@ func getTypes(fileInfo *FileInfo, f fileHandler, md moduledata) (map[uint64]*GoT
 		return getLegacyTypes(fileInfo, f, md)
 	}
 
+	// The cache is not limited since all the types are kept anyway. This way
+	// the types referenced by the returned types are the same objects.
+	types := make(map[uint64]*GoType)
+	err := iterTypes(fileInfo, f, md, 0, func(t *GoType) bool {
+		types[t.Addr] = t
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+// typesIterCacheLimit is the number of parsed types the parser keeps in its
+// cache while types are iterated over by TypesIter.
+const typesIterCacheLimit = 4096
+
+// iterTypes parses the types in the binary and calls yield for each type
+// once it has been parsed. The iteration is stopped if yield returns false.
+// If cacheLimit is larger than zero, the parser's cache is cleared between
+// top-level types once it holds more than cacheLimit types. Types that are
+// parsed again after the cache has been cleared are not yielded again.
+func iterTypes(fileInfo *FileInfo, f fileHandler, md moduledata, cacheLimit int, yield func(*GoType) bool) error {
+	if GoVersionCompare(fileInfo.goversion.Name, "go1.7beta1") < 0 {
+		// The legacy types are parsed all at once.
+		types, err := getLegacyTypes(fileInfo, f, md)
+		if err != nil {
+			return err
+		}
+		for _, t := range types {
+			if !yield(t) {
+				return nil
+			}
+		}
+		return nil
+	}
+
 	types, err := md.Types().Data()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get types data section: %w", err)
+		return fmt.Errorf("failed to get types data section: %w", err)
 	}
 
 	typeLink, err := md.TypeLinkData()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get type link data: %w", err)
+		return fmt.Errorf("failed to get type link data: %w", err)
 	}
 
 	// New parser
 	parser := newTypeParser(types, md.Types().Address, fileInfo)
+	var yielded map[uint64]struct{}
+	if cacheLimit > 0 {
+		yielded = make(map[uint64]struct{})
+	}
 	for _, off := range typeLink {
 		typ, err := parser.parseType(uint64(off) + parser.base)
 		if err != nil || typ == nil {
-			return nil, fmt.Errorf("failed to parse type at offset 0x%x: %w", off, err)
+			return fmt.Errorf("failed to parse type at offset 0x%x: %w", off, err)
+		}
+		// Parsing a type also parses the types it references so yield
+		// all of them.
+		for _, t := range parser.takeNewTypes() {
+			if yielded != nil {
+				if _, ok := yielded[t.Addr]; ok {
+					continue
+				}
+				yielded[t.Addr] = struct{}{}
+			}
+			if !yield(t) {
+				return nil
+			}
+		}
+		if cacheLimit > 0 && len(parser.cache) > cacheLimit {
+			parser.cache = make(map[uint64]*GoType)
 		}
 	}
-	return parser.parsedTypes(), nil
+	return nil
+}
+
+// getTypeNames returns the names of the types in the typelinks. The types
+// are not fully parsed, only their names are resolved.
+func getTypeNames(fileInfo *FileInfo, f fileHandler, md moduledata) ([]string, error) {
+	seen := make(map[string]struct{})
+
+	if GoVersionCompare(fileInfo.goversion.Name, "go1.7beta1") < 0 {
+		// The legacy parser resolves the types fully.
+		types, err := getLegacyTypes(fileInfo, f, md)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range types {
+			if t.Name != "" {
+				seen[qualifyTypeName(t.Name, t.PackagePath)] = struct{}{}
+			}
+		}
+	} else {
+		types, err := md.Types().Data()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get types data section: %w", err)
+		}
+
+		typeLink, err := md.TypeLinkData()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get type link data: %w", err)
+		}
+
+		parser := newTypeParser(types, md.Types().Address, fileInfo)
+		for _, off := range typeLink {
+			name, err := parser.parseTypeName(uint64(off) + parser.base)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse type name at offset 0x%x: %w", off, err)
+			}
+			if name != "" {
+				seen[name] = struct{}{}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for n := range seen {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// typeLiteralPrefixes are the prefixes of the names of type literals.
+var typeLiteralPrefixes = []string{"*", "[", "map[", "func(", "chan ", "chan<-", "<-chan", "struct {", "interface {"}
+
+// isNamedTypeName returns true if the type name is the name of a named type,
+// for example "http.Client", instead of a type literal like "[]int". The
+// predeclared types, for example "int" and "error", are named types whose
+// names have no package qualifier.
+func isNamedTypeName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, prefix := range typeLiteralPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNamed returns true if the type is a named type or a predeclared type.
+// Unnamed types are type literals, for example "[]int" or "struct { a int }".
+func (t *GoType) IsNamed() bool {
+	return isNamedTypeName(t.Name)
+}
+
+// QualifiedName returns the name of the type qualified with its package path,
+// for example "net/http.Client" for a named type. Predeclared types, such as
+// "int", and named types without a known package path are returned with their
+// name. Unnamed types are returned with their structural description, for
+// example "[]*http.Request", as rendered by String.
+func (t *GoType) QualifiedName() string {
+	if !t.IsNamed() {
+		return t.String()
+	}
+	if t.PackagePath == "" {
+		return t.Name
+	}
+	_, n, ok := strings.Cut(t.Name, ".")
+	if !ok {
+		n = t.Name
+	}
+	return t.PackagePath + "." + n
+}
+
+// Equal returns true if the types have the same structure. The kinds, the
+// names, the package paths, the field information and the nested types, such
+// as the fields, the element and key types, the function signatures and the
+// methods, are compared recursively. The addresses are ignored, so types from
+// different binaries can be compared, for example to find the types that
+// changed between two versions of a program. A pair of types that is reached
+// again while it's being compared, for example a struct with a pointer to
+// itself, is treated as equal.
+func (t *GoType) Equal(other *GoType) bool {
+	return t.equal(other, make(map[[2]*GoType]struct{}))
+}
+
+// equal compares the types with seen holding the pairs of types that are
+// being compared by the callers.
+func (t *GoType) equal(other *GoType, seen map[[2]*GoType]struct{}) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	pair := [2]*GoType{t, other}
+	if _, ok := seen[pair]; ok {
+		return true
+	}
+	seen[pair] = struct{}{}
+
+	if t.Kind != other.Kind || t.Name != other.Name || t.PackagePath != other.PackagePath ||
+		t.FieldName != other.FieldName || t.FieldTag != other.FieldTag || t.FieldAnon != other.FieldAnon ||
+		t.FieldOffset != other.FieldOffset || t.Length != other.Length || t.ChanDir != other.ChanDir ||
+		t.IsVariadic != other.IsVariadic || t.Truncated != other.Truncated {
+		return false
+	}
+	if !t.Element.equal(other.Element, seen) || !t.Key.equal(other.Key, seen) {
+		return false
+	}
+	if !equalTypes(t.Fields, other.Fields, seen) || !equalTypes(t.FuncArgs, other.FuncArgs, seen) ||
+		!equalTypes(t.FuncReturnVals, other.FuncReturnVals, seen) {
+		return false
+	}
+	if len(t.Methods) != len(other.Methods) {
+		return false
+	}
+	for i, m := range t.Methods {
+		o := other.Methods[i]
+		if m == nil || o == nil {
+			if m != o {
+				return false
+			}
+			continue
+		}
+		if m.Name != o.Name || !m.Type.equal(o.Type, seen) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalTypes compares the types in the slices pairwise.
+func equalTypes(a, b []*GoType, seen map[[2]*GoType]struct{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].equal(b[i], seen) {
+			return false
+		}
+	}
+	return true
+}
+
+// qualifyTypeName replaces the package name in the name of a named type with
+// the package path. For example "http.Client" with the package path "net/http"
+// results in "net/http.Client". Other names, including the names without a
+// package qualifier, are returned unchanged.
+func qualifyTypeName(name, pkgPath string) string {
+	if pkgPath == "" || !isNamedTypeName(name) {
+		return name
+	}
+	_, n, ok := strings.Cut(name, ".")
+	if !ok {
+		return name
+	}
+	return pkgPath + "." + n
 }
 
 func getLegacyTypes(fileInfo *FileInfo, f fileHandler, md moduledata) (map[uint64]*GoType, error) {
@@ -132,6 +365,9 @@ type GoType struct {
 	FieldTag string
 	// FieldAnon is true if the field does not have a name and is an embedded type.
 	FieldAnon bool
+	// FieldOffset is the byte offset of the field within the struct if the GoType
+	// is a struct field.
+	FieldOffset uint64
 	// Element is the element type for arrays, slices channels or the resolved type for
 	// a pointer type. For example int if the slice is a []int.
 	Element *GoType
@@ -149,18 +385,54 @@ type GoType struct {
 	IsVariadic bool
 	// Methods holds information of the types methods.
 	Methods []*TypeMethod
-	flag    uint8
+	// Truncated is true if the type is nested too deep to be parsed, see
+	// GoFile.SetMaxTypeDepth. Only the kind and the name are set.
+	Truncated bool
+	flag      uint8
 }
 
 // String implements the fmt.Stringer interface.
 func (t *GoType) String() string {
+	return t.StringDepth(-1)
+}
+
+// StringDepth returns the string representation of the type, like String, but
+// the nested types are only rendered to the given number of levels. Types
+// nested deeper are rendered as "...". A negative depth means no limit. A type
+// that refers back to itself, for example a pointer type to itself, is rendered
+// with its name, or as "..." if it doesn't have a name.
+func (t *GoType) StringDepth(depth int) string {
+	return t.stringDepth(depth, nil)
+}
+
+// stringDepth renders the type with seen holding the types that are being
+// rendered by the callers.
+func (t *GoType) stringDepth(depth int, seen []*GoType) string {
+	for _, s := range seen {
+		if s == t {
+			if t.Name != "" {
+				return t.Name
+			}
+			return "..."
+		}
+	}
+	nested := func(n *GoType) string {
+		if n == nil {
+			return "<nil>"
+		}
+		if depth == 0 {
+			return "..."
+		}
+		return n.stringDepth(depth-1, append(seen, t))
+	}
+
 	switch t.Kind {
 	case reflect.Slice:
-		return fmt.Sprintf("[]%s", t.Element)
+		return "[]" + nested(t.Element)
 	case reflect.Array:
-		return fmt.Sprintf("[%d]%s", t.Length, t.Element)
+		return fmt.Sprintf("[%d]%s", t.Length, nested(t.Element))
 	case reflect.Map:
-		return fmt.Sprintf("map[%s]%s", t.Key, t.Element)
+		return fmt.Sprintf("map[%s]%s", nested(t.Key), nested(t.Element))
 	case reflect.Struct:
 		// Handle empty struct type
 		if t.Name == "" {
@@ -168,15 +440,15 @@ func (t *GoType) String() string {
 		}
 		return t.Name
 	case reflect.Ptr:
-		return fmt.Sprintf("*%s", t.Element)
+		return "*" + nested(t.Element)
 	case reflect.Chan:
 		if t.ChanDir == ChanRecv {
-			return fmt.Sprintf("<-chan %s", t.Element)
+			return "<-chan " + nested(t.Element)
 		}
 		if t.ChanDir == ChanSend {
-			return fmt.Sprintf("chan<- %s", t.Element)
+			return "chan<- " + nested(t.Element)
 		}
-		return fmt.Sprintf("chan %s", t.Element)
+		return "chan " + nested(t.Element)
 	case reflect.Func:
 		buf := "func("
 		for i, a := range t.FuncArgs {
@@ -186,7 +458,7 @@ func (t *GoType) String() string {
 			if a.Kind == reflect.Func && a.Name == t.Name {
 				buf += a.Name
 			} else {
-				buf += a.String()
+				buf += nested(a)
 			}
 		}
 		if len(t.FuncReturnVals) > 1 {
@@ -203,7 +475,7 @@ func (t *GoType) String() string {
 			if r.Kind == reflect.Func && r.Name == t.Name {
 				buf += r.Name
 			} else {
-				buf += r.String()
+				buf += nested(r)
 			}
 		}
 		if len(t.FuncReturnVals) > 1 {
@@ -470,6 +742,9 @@ func typeParse(types map[uint64]*GoType, fileInfo *FileInfo, offset uint64, sect
 				return nil
 			}
 			gt := typeParse(types, fileInfo, tptr-sectionBaseAddr, sectionData, sectionBaseAddr)
+			if gt == nil {
+				return nil
+			}
 			// Make a copy
 			field := *gt
 
@@ -481,6 +756,7 @@ func typeParse(types map[uint64]*GoType, fileInfo *FileInfo, offset uint64, sect
 			// Older versions has no field name for anonymous fields. New versions
 			// uses a bit flag on the offset.
 			field.FieldAnon = fieldName == "" || uptr&1 != 0
+			field.FieldOffset = uptr
 			typ.Fields[i] = &field
 		}
 	case reflect.Array:
@@ -689,7 +965,7 @@ func parseString(fileInfo *FileInfo, off, base uint64, baseData []byte) string {
 	if err != nil {
 		return ""
 	}
-	if h == 0 || l == 0 {
+	if h == 0 || l == 0 || h < base || h-base > uint64(len(baseData)) || l > uint64(len(baseData))-(h-base) {
 		return ""
 	}
 	str := string(baseData[h-base : h-base+l])
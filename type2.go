@@ -31,10 +31,14 @@ import (
 
 */
 
+// defaultMaxTypeDepth is the default limit on how deep nested types are parsed.
+const defaultMaxTypeDepth = 1024
+
 func newTypeParser(typesData []byte, baseAddres uint64, fi *FileInfo) *typeParser {
 	goversion := fi.goversion.Name
 
 	p := &typeParser{
+		maxDepth:  fi.maxTypeDepth,
 		goversion: goversion,
 		base:      baseAddres,
 		order:     fi.ByteOrder,
@@ -97,11 +101,19 @@ func newTypeParser(typesData []byte, baseAddres uint64, fi *FileInfo) *typeParse
 
 	if GoVersionCompare(fi.goversion.Name, "go1.17beta1") < 0 {
 		// before go1.17, the length of tag used fixed 2-byte encoding.
-		p.parseNameLen = nameLenParseFuncTwoByteFixed
-	} else {
+		p.parseName = nameParseFuncPre117
+	} else if GoVersionCompare(fi.goversion.Name, "go1.19rc1") < 0 {
 		// See https://golang.org/cl/318249.
 		// Go1.17 switch to using varint encoding.
-		p.parseNameLen = nameLenParseFuncVarint
+		p.parseName = nameParseFunc117
+	} else {
+		// Go1.19 moved the embedded flag from the struct field offset to
+		// the name.
+		p.parseName = nameParseFunc119
+	}
+
+	if p.maxDepth <= 0 {
+		p.maxDepth = defaultMaxTypeDepth
 	}
 
 	return p
@@ -122,6 +134,13 @@ type typeParser struct {
 	wordsize int
 	// cache is used to track types that has already been parsed.
 	cache map[uint64]*GoType
+	// newTypes holds the types that have been parsed since the last call to
+	// the method "takeNewTypes".
+	newTypes []*GoType
+	// depth is the number of types currently being parsed by the recursive
+	// calls to "parseType" and maxDepth is the limit for it.
+	depth    int
+	maxDepth int
 
 	// typesData is the byte slice of the types data.
 	// located.
@@ -143,38 +162,22 @@ type typeParser struct {
 	parseStructType      structTypeParseFunc
 	parseUint            readUintFunc
 	parseUncommon        uncommonTypeParseFunc
-	parseNameLen         nameLenParseFunc
-}
-
-func (p *typeParser) hasTag(off uint64) bool {
-	return p.typesData[off]&(1<<1) != 0
+	parseName            nameParseFunc
 }
 
+// resolveName returns the name at the offset into the types data and its
+// length. If the type flags has the extra star flag set, the leading star is
+// removed. A name that can't be decoded is returned as an empty string.
 func (p *typeParser) resolveName(ptr uint64, flags uint8) (string, int) {
-	i, l := p.parseNameLen(p, ptr+1)
-	name := string(p.typesData[ptr+1+uint64(l) : ptr+1+uint64(l)+i])
-	nl := int(i)
-	if nl == 0 {
+	n, err := p.parseName(p, ptr)
+	if err != nil || n.name == "" {
 		return "", 0
 	}
 	if flags&tflagExtraStar != 0 {
 		// typ.Name = strData[1:]
-		return name[1:], nl - 1
+		return n.name[1:], len(n.name) - 1
 	}
-	return name, nl
-}
-
-func (p *typeParser) resolveTag(o uint64) string {
-	if !p.hasTag(o) {
-		return ""
-	}
-	nl, nll := p.parseNameLen(p, o+1)
-	tl, tll := p.parseNameLen(p, o+1+nl+uint64(nll))
-	if tl == 0 {
-		return ""
-	}
-	o += 1 + nl + uint64(nll+tll)
-	return string(p.typesData[o : o+tl])
+	return n.name, len(n.name)
 }
 
 func (p *typeParser) readType(obj interface{}) (int, error) {
@@ -196,6 +199,108 @@ func (p *typeParser) parsedTypes() map[uint64]*GoType {
 	return p.cache
 }
 
+// takeNewTypes returns the types that have been parsed since the last call
+// to this method. The types are fully parsed once the call to "parseType"
+// that parsed them has returned.
+func (p *typeParser) takeNewTypes() []*GoType {
+	t := p.newTypes
+	p.newTypes = nil
+	return t
+}
+
+// structFieldOffset returns the byte offset of a struct field from the
+// field's "offsetEmbed" value. Between Go 1.9 and 1.18 the offset is shifted
+// to make room for the embedded flag in the lowest bit. An empty version is
+// treated as older than Go 1.9 so the value is returned as is.
+func structFieldOffset(offsetEmbed uint64, goversion string) uint64 {
+	if GoVersionCompare(goversion, "go1.9beta1") >= 0 && GoVersionCompare(goversion, "go1.19beta1") < 0 {
+		return offsetEmbed >> 1
+	}
+	return offsetEmbed
+}
+
+// kindData holds the kind specific data structure that is located right
+// after the rtype structure. Only the field for the type's kind is set.
+type kindData struct {
+	array      arrayType64
+	chanType   chanType
+	funcType   funcType
+	iface      interfaceType
+	mapType    mapType
+	structType structType64
+	// elem is the address of the element type for pointers and slices.
+	elem uint64
+}
+
+// parseKindData parses the kind specific data for a type of the given kind.
+// The reader has to be positioned at the end of the type's rtype structure.
+// The number of bytes read is returned. The address of the type is only used
+// for the error message.
+func (p *typeParser) parseKindData(kind reflect.Kind, address uint64) (kindData, int, error) {
+	var kd kindData
+	var c int
+	var err error
+
+	switch kind {
+	case reflect.Array:
+		kd.array, c, err = p.parseArrayType(p)
+	case reflect.Chan:
+		kd.chanType, c, err = p.parseChanType(p)
+	case reflect.Func:
+		kd.funcType, c, err = p.parseFuncType(p)
+	case reflect.Interface:
+		kd.iface, c, err = p.parseInterface(p)
+	case reflect.Map:
+		kd.mapType, c, err = p.parseMap(p)
+	case reflect.Ptr, reflect.Slice:
+		kd.elem, c, err = p.parseUint(p)
+	case reflect.Struct:
+		kd.structType, c, err = p.parseStructType(p)
+	}
+	if err != nil {
+		return kd, 0, fmt.Errorf("failed to parse fields for %s type located at 0x%x: %w", kind, address, err)
+	}
+	return kd, c, nil
+}
+
+// parseTypeName resolves the name of the type at the given address without
+// parsing the types it references. If the type is a named type and its
+// package path is known, the package name in the type name is replaced
+// with the package path.
+func (p *typeParser) parseTypeName(address uint64) (string, error) {
+	err := p.seekFromStart(address - p.base)
+	if err != nil {
+		return "", err
+	}
+
+	rtype, _, err := p.parseRtype(p)
+	if err != nil {
+		return "", err
+	}
+	name, _ := p.resolveName(uint64(rtype.Str), rtype.Tflag)
+
+	if rtype.Tflag&tflagUncommon == 0 || !isNamedTypeName(name) {
+		return name, nil
+	}
+
+	// The uncommon type is located after the kind specific data so it
+	// needs to be skipped first.
+	_, _, err = p.parseKindData(reflect.Kind(rtype.Kind&kindMask), address)
+	if err != nil {
+		return "", err
+	}
+
+	uc, _, err := p.parseUncommon(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse type's (0x%x) uncommon field data: %w", address, err)
+	}
+	if uc.PkgPath <= 0 || int(uc.PkgPath) >= len(p.typesData) {
+		return name, nil
+	}
+	pkgPath, _ := p.resolveName(uint64(uc.PkgPath), 0)
+	return qualifyTypeName(name, pkgPath), nil
+}
+
 // parseType parses the type at the given offset. This method does return
 // the parsed type, but this should not be used to get all types. This
 // functionality is used internally because the method is called recursively
@@ -228,13 +333,25 @@ func (p *typeParser) parseType(address uint64) (*GoType, error) {
 	}
 	count += c
 
-	// Create a new type and store it in the cache.
 	typ := &GoType{
 		Kind: reflect.Kind(rtype.Kind & kindMask),
 		flag: rtype.Tflag,
 		Addr: uint64(address),
 	}
+	if p.depth >= p.maxDepth {
+		// The type is nested too deep, for example in type data crafted to
+		// exhaust the stack. Only the name is resolved and the type is not
+		// cached so it's parsed fully if it's reached by a shorter path.
+		typ.Name, _ = p.resolveName(uint64(rtype.Str), typ.flag)
+		typ.Truncated = true
+		return typ, nil
+	}
+	p.depth++
+	defer func() { p.depth-- }()
+
+	// Store the new type in the cache.
 	p.cache[address] = typ
+	p.newTypes = append(p.newTypes, typ)
 
 	// Resolve name of the type.
 	typ.Name, _ = p.resolveName(uint64(rtype.Str), typ.flag)
@@ -253,48 +370,31 @@ func (p *typeParser) parseType(address uint64) (*GoType, error) {
 	var child uint64
 	var key uint64
 
+	kd, c, err := p.parseKindData(typ.Kind, address)
+	if err != nil {
+		return nil, err
+	}
+	count += c
+
 	switch typ.Kind {
 
 	case reflect.Array:
-		a, c, err := p.parseArrayType(p)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse fields for array located at 0x%x: %w", address, err)
-		}
-		count += c
-
-		typ.Length = int(a.Len)
-		child = a.Eem
+		typ.Length = int(kd.array.Len)
+		child = kd.array.Eem
 
 	case reflect.Chan:
-		ch, c, err := p.parseChanType(p)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse fields for channel type located at 0x%x: %w", address, err)
-		}
-		count += c
-
-		typ.ChanDir = ChanDir(int(ch.Dir))
-		child = ch.Elem
+		typ.ChanDir = ChanDir(int(kd.chanType.Dir))
+		child = kd.chanType.Elem
 
 	case reflect.Func:
-		ftype, c, err := p.parseFuncType(p)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse fields for function type located at 0x%x: %w", address, err)
-		}
-		count += c
-
-		typ.FuncArgs = make([]*GoType, int(ftype.InCount))
-		typ.IsVariadic = ftype.OutCount&(1<<15) != 0
+		typ.FuncArgs = make([]*GoType, int(kd.funcType.InCount))
+		typ.IsVariadic = kd.funcType.OutCount&(1<<15) != 0
 
-		out := ftype.OutCount & (1<<15 - 1)
+		out := kd.funcType.OutCount & (1<<15 - 1)
 		typ.FuncReturnVals = make([]*GoType, out)
 
 	case reflect.Interface:
-		iface, c, err := p.parseInterface(p)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse fields for interface type located at 0x%x: %w", address, err)
-		}
-		count += c
-
+		iface := kd.iface
 		if iface.PkgPath != 0 {
 			typ.PackagePath, _ = p.resolveName(iface.PkgPath-p.base, 0)
 		}
@@ -305,35 +405,18 @@ func (p *typeParser) parseType(address uint64) (*GoType, error) {
 		}
 
 	case reflect.Map:
-		maptyp, c, err := p.parseMap(p)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse fields for map type located at 0x%x: %w", address, err)
-		}
-		count += c
-
-		child = maptyp.Elem
-		key = maptyp.Key
+		child = kd.mapType.Elem
+		key = kd.mapType.Key
 
 	case reflect.Ptr, reflect.Slice:
-		ptr, c, err := p.parseUint(p)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse pointer to type for type located at 0x%x: %w", address, err)
-		}
-		count += c
-
-		child = ptr
+		child = kd.elem
 
 		if typ.Kind == reflect.Ptr {
-			typ.PtrResolvAddr = ptr
+			typ.PtrResolvAddr = kd.elem
 		}
 
 	case reflect.Struct:
-		s, c, err := p.parseStructType(p)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse struct type's fields located at 0x%x: %w", address, err)
-		}
-		count += c
-
+		s := kd.structType
 		child = s.FieldsData
 		typ.Fields = make([]*GoType, int(s.FieldsLen), int(s.FieldsCap))
 
@@ -581,22 +664,22 @@ func (p *typeParser) parseType(address uint64) (*GoType, error) {
 				// over and over again.
 				field := *gt
 
-				name, nl := p.resolveName(sf.Name-p.base, 0)
-				field.FieldName = name
-
-				if nl != 0 {
-					field.FieldTag = p.resolveTag(sf.Name - p.base)
-				}
+				// A field name that can't be decoded is treated as empty.
+				fn, _ := p.parseName(p, sf.Name-p.base)
+				field.FieldName = fn.name
+				field.FieldTag = fn.tag
 
 				// In the commit https://github.com/golang/go/commit/e1e66a03a6bb3210034b640923fa253d7def1a26 the encoding for
 				// embedded struct field was moved from the offset field to the name field. This changed was first part of the
 				// 1.19rc1 release.s
 				if GoVersionCompare(p.goversion, "go1.19rc1") >= 0 {
-					field.FieldAnon = p.typesData[sf.Name-p.base]&(1<<3) != 0
+					field.FieldAnon = fn.embedded
 				} else {
-					field.FieldAnon = name == "" || sf.OffsetEmbed&1 != 0
+					field.FieldAnon = fn.name == "" || sf.OffsetEmbed&1 != 0
 				}
 
+				field.FieldOffset = structFieldOffset(sf.OffsetEmbed, p.goversion)
+
 				typ.Fields[i] = &field
 			}
 		}
@@ -993,13 +1076,88 @@ var uncommonTypeParseFunc17Beta1 = func(p *typeParser) (uncommonType, int, error
 	}, c, err
 }
 
+// The bits of the flag byte that starts an encoded name.
+const (
+	nameFlagHasTag uint8 = 1 << 1
+	// nameFlagEmbedded is only used from Go 1.19.
+	nameFlagEmbedded uint8 = 1 << 3
+)
+
+// typeName is a decoded name from the types data. It is the equivalent of the
+// runtime's name structure.
+type typeName struct {
+	name     string
+	tag      string
+	embedded bool
+}
+
+type nameParseFunc func(p *typeParser, offset uint64) (typeName, error)
+
+var nameParseFuncPre117 = func(p *typeParser, offset uint64) (typeName, error) {
+	return p.readName(offset, nameLenParseFuncTwoByteFixed, false)
+}
+
+var nameParseFunc117 = func(p *typeParser, offset uint64) (typeName, error) {
+	return p.readName(offset, nameLenParseFuncVarint, false)
+}
+
+var nameParseFunc119 = func(p *typeParser, offset uint64) (typeName, error) {
+	return p.readName(offset, nameLenParseFuncVarint, true)
+}
+
+// readName decodes the name at the offset into the types data. The encoding
+// is a flag byte followed by the length prefixed name. If the flag says so,
+// the name is followed by a length prefixed tag. The embedded flag is only
+// honoured if hasEmbedded is true.
+func (p *typeParser) readName(offset uint64, parseLen nameLenParseFunc, hasEmbedded bool) (typeName, error) {
+	if offset >= uint64(len(p.typesData)) {
+		return typeName{}, fmt.Errorf("name offset 0x%x is out of bounds", offset)
+	}
+	flag := p.typesData[offset]
+	n := typeName{embedded: hasEmbedded && flag&nameFlagEmbedded != 0}
+
+	off := offset + 1
+	readStr := func() (string, error) {
+		l, ll := parseLen(p, off)
+		if ll <= 0 {
+			return "", fmt.Errorf("malformed length for name at 0x%x", offset)
+		}
+		start := off + uint64(ll)
+		if l > uint64(len(p.typesData)) || start+l > uint64(len(p.typesData)) {
+			return "", fmt.Errorf("name at 0x%x is out of bounds", offset)
+		}
+		off = start + l
+		return string(p.typesData[start:off]), nil
+	}
+
+	var err error
+	if n.name, err = readStr(); err != nil {
+		return typeName{}, err
+	}
+	if flag&nameFlagHasTag != 0 {
+		if n.tag, err = readStr(); err != nil {
+			return typeName{}, err
+		}
+	}
+	return n, nil
+}
+
+// nameLenParseFunc returns the length at the offset and the number of bytes
+// used to encode it. A non-positive number of bytes means the length could
+// not be read.
 type nameLenParseFunc func(p *typeParser, offset uint64) (uint64, int)
 
 var nameLenParseFuncTwoByteFixed = func(p *typeParser, offset uint64) (uint64, int) {
+	if offset+2 > uint64(len(p.typesData)) {
+		return 0, 0
+	}
 	return uint64(uint16(p.typesData[offset])<<8 | uint16(p.typesData[offset+1])), 2
 }
 
 var nameLenParseFuncVarint = func(p *typeParser, offset uint64) (uint64, int) {
+	if offset >= uint64(len(p.typesData)) {
+		return 0, 0
+	}
 	return binary.Uvarint(p.typesData[offset:])
 }
 
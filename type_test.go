@@ -18,6 +18,8 @@
 package gore
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"os"
 	"reflect"
@@ -57,8 +59,7 @@ func TestGetTypes(t *testing.T) {
 			var complexStructTested bool
 			var stringerInterfaceTested bool
 			for _, typ := range typs {
-				if typ.Name == "fmt.Stringer" && typ.PackagePath == "fmt" &&
-					GoVersionCompare(f.FileInfo.goversion.Name, "go1.7beta1") >= 0 {
+				if typ.Name == "fmt.Stringer" && typ.PackagePath == "fmt" {
 					a.Equal(reflect.Interface, typ.Kind, "Stringer should be an interface")
 					a.Len(typ.Methods, 1, "Stringer should have 1 function defined")
 					a.Equal("String", typ.Methods[0].Name, "Stringer's function should have the name of String")
@@ -79,8 +80,7 @@ func TestGetTypes(t *testing.T) {
 					simpleStructTested = true
 				}
 
-				if typ.Name == "main.myComplexStruct" && typ.PackagePath == "main" &&
-					GoVersionCompare(f.FileInfo.goversion.Name, "go1.7beta1") >= 0 {
+				if typ.Name == "main.myComplexStruct" && typ.PackagePath == "main" {
 					a.Equal(reflect.Struct, typ.Kind, "myComplexStruct parsed as wrong type")
 					a.Len(typ.Fields, 8, "myComplexStruct should have 7 fields")
 
@@ -138,17 +138,18 @@ func TestGetTypes(t *testing.T) {
 					complexStructTested = true
 				}
 
-				if typ.Name == "cpu.option" && typ.PackagePath == "" &&
-					GoVersionCompare(f.FileInfo.goversion.Name, "go1.7beta1") >= 0 {
+				if typ.Name == "cpu.option" && typ.PackagePath == "" {
 					for _, field := range typ.Fields {
 						a.Equal("", field.FieldTag, "Field Tag should be empty")
 					}
 				}
 			}
+			// Before Go 1.7, the typelinks only hold unnamed types so the
+			// interface is not reachable from them.
 			if GoVersionCompare(f.FileInfo.goversion.Name, "go1.7beta1") >= 0 {
-				a.True(complexStructTested, "myComplexStruct was not found")
 				a.True(stringerInterfaceTested, "fmt.Stringer was not found")
 			}
+			a.True(complexStructTested, "myComplexStruct was not found")
 			a.True(simpleStructTested, "simpleStruct was not found")
 		})
 	}
@@ -174,6 +175,8 @@ func TestGoTypeStringer(t *testing.T) {
 		{&GoType{Kind: reflect.Uint16}, "uint16"},
 		{&GoType{Kind: reflect.Uint32}, "uint32"},
 		{&GoType{Kind: reflect.Uint64}, "uint64"},
+		{&GoType{Kind: reflect.Uintptr}, "uintptr"},
+		{&GoType{Kind: reflect.UnsafePointer}, "unsafe.Pointer"},
 		{&GoType{Kind: reflect.Slice, Element: &GoType{Kind: reflect.Int}}, "[]int"},
 		{&GoType{Kind: reflect.Array, Element: &GoType{Kind: reflect.Uint}, Length: 10}, "[10]uint"},
 		{&GoType{Kind: reflect.Map, Element: &GoType{Kind: reflect.Uint}, Key: &GoType{Kind: reflect.String}}, "map[string]uint"},
@@ -214,6 +217,34 @@ func TestGoTypeStringer(t *testing.T) {
 	}
 }
 
+func TestGoTypeStringDepth(t *testing.T) {
+	r := require.New(t)
+
+	nested := &GoType{Kind: reflect.Map, Key: &GoType{Kind: reflect.String}, Element: &GoType{
+		Kind:    reflect.Slice,
+		Element: &GoType{Kind: reflect.Ptr, Element: &GoType{Kind: reflect.Struct, Name: "main.T"}},
+	}}
+	r.Equal("map[string][]*main.T", nested.String())
+	r.Equal("map[string][]*main.T", nested.StringDepth(-1))
+	r.Equal("map[string][]*main.T", nested.StringDepth(3))
+	r.Equal("map[string][]*...", nested.StringDepth(2))
+	r.Equal("map[...]...", nested.StringDepth(0))
+
+	// type P *P
+	ptr := &GoType{Kind: reflect.Ptr, Name: "main.P"}
+	ptr.Element = ptr
+	r.Equal("*main.P", ptr.String())
+
+	// An unnamed cycle, for example from a corrupt binary.
+	slice := &GoType{Kind: reflect.Slice}
+	slice.Element = &GoType{Kind: reflect.Chan, Element: slice}
+	r.Equal("[]chan ...", slice.String())
+
+	// The same type can be used more than once without being a cycle.
+	elem := &GoType{Kind: reflect.Int}
+	r.Equal("map[int]int", (&GoType{Kind: reflect.Map, Key: elem, Element: elem}).String())
+}
+
 func TestStructDef(t *testing.T) {
 	assert := assert.New(t)
 	tests := []struct {
@@ -256,6 +287,14 @@ func TestStructDef(t *testing.T) {
 			Fields: []*GoType{
 				{FieldName: "myString", Kind: reflect.String, FieldTag: `json:"String"`},
 			}}, structWithFieldTag},
+		{&GoType{
+			Kind: reflect.Struct,
+			Name: "runtime.hmap",
+			Fields: []*GoType{
+				{FieldName: "buckets", Kind: reflect.UnsafePointer},
+				{FieldName: "nevacuate", Kind: reflect.Uintptr},
+				{FieldName: "extra", Kind: reflect.Ptr, Element: &GoType{Kind: reflect.UnsafePointer}},
+			}}, "type runtime.hmap struct{\n\tbuckets unsafe.Pointer\n\tnevacuate uintptr\n\textra *unsafe.Pointer\n}"},
 	}
 	for _, test := range tests {
 		assert.Equal(test.expected, StructDef(test.typ))
@@ -344,3 +383,274 @@ const ifDef = `type geometry interface {
 const methodAll = `func (myStruct) Read([]int8) (int, error)
 func (myStruct) Close() error
 func (myStruct) private()`
+
+func TestGoTypeIsNamed(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"main.simpleStruct", true},
+		{"int", true},
+		{"error", true},
+		{"", false},
+		{"*main.simpleStruct", false},
+		{"[]int", false},
+		{"[2]int", false},
+		{"map[string]int", false},
+		{"func(int) string", false},
+		{"chan int", false},
+		{"<-chan int", false},
+		{"struct { a int }", false},
+		{"struct {}", false},
+		{"interface {}", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, (&GoType{Name: test.name}).IsNamed())
+			assert.Equal(t, test.expected, isNamedTypeName(test.name))
+		})
+	}
+}
+
+func TestQualifyTypeName(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkgPath string
+		want    string
+	}{
+		{"http.Client", "net/http", "net/http.Client"},
+		{"main.List[main.T]", "main", "main.List[main.T]"},
+		{"sync.Mutex", "", "sync.Mutex"},
+		{"*http.Client", "net/http", "*http.Client"},
+		{"[]http.Header", "net/http", "[]http.Header"},
+		{"map[string]int", "", "map[string]int"},
+		{"func(http.Header)", "net/http", "func(http.Header)"},
+		{"chan time.Time", "time", "chan time.Time"},
+		{"struct { runtime.gList; n int32 }", "runtime", "struct { runtime.gList; n int32 }"},
+		{"int", "", "int"},
+		{"error", "main", "error"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, qualifyTypeName(test.name, test.pkgPath))
+		})
+	}
+}
+
+func TestGoTypeQualifiedName(t *testing.T) {
+	client := &GoType{Kind: reflect.Struct, Name: "http.Client", PackagePath: "net/http"}
+	tests := []struct {
+		name string
+		typ  *GoType
+		want string
+	}{
+		{"named", client, "net/http.Client"},
+		{"named without package path", &GoType{Kind: reflect.Struct, Name: "http.Client"}, "http.Client"},
+		{"predeclared", &GoType{Kind: reflect.Int, Name: "int"}, "int"},
+		{"pointer", &GoType{Kind: reflect.Ptr, Name: "*http.Client", Element: client}, "*http.Client"},
+		{"slice", &GoType{Kind: reflect.Slice, Name: "[]int", Element: &GoType{Kind: reflect.Int, Name: "int"}}, "[]int"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.typ.QualifiedName())
+		})
+	}
+}
+
+func TestGoTypeEqual(t *testing.T) {
+	// newConfig returns the type "main.Config" with a field holding a pointer
+	// to itself and a string field with the given tag.
+	newConfig := func(addr uint64, tag string) *GoType {
+		config := &GoType{Kind: reflect.Struct, Name: "main.Config", PackagePath: "main", Addr: addr}
+		next := &GoType{Kind: reflect.Ptr, Name: "*main.Config", Element: config, PtrResolvAddr: addr, FieldName: "next"}
+		name := &GoType{Kind: reflect.String, Name: "string", FieldName: "name", FieldTag: tag, FieldOffset: 8}
+		config.Fields = []*GoType{next, name}
+		config.Methods = []*TypeMethod{{Name: "Load", Type: &GoType{Kind: reflect.Func, Name: "func() error"}, FuncCallOffset: addr}}
+		return config
+	}
+
+	a := assert.New(t)
+	config := newConfig(0x1000, `json:"name"`)
+	a.True(config.Equal(config))
+	a.True(config.Equal(newConfig(0x2000, `json:"name"`)), "addresses should be ignored")
+	a.False(config.Equal(newConfig(0x1000, `json:"other"`)))
+	a.False(config.Equal(nil))
+	a.True((*GoType)(nil).Equal(nil))
+
+	changed := newConfig(0x1000, `json:"name"`)
+	changed.Fields = changed.Fields[:1]
+	a.False(config.Equal(changed))
+
+	changed = newConfig(0x1000, `json:"name"`)
+	changed.Methods[0].Type.IsVariadic = true
+	a.False(config.Equal(changed))
+}
+
+func TestStructFieldOffset(t *testing.T) {
+	tests := []struct {
+		name        string
+		goversion   string
+		offsetEmbed uint64
+		expected    uint64
+	}{
+		{"go1.8 unshifted", "go1.8", 0x10, 0x10},
+		{"go1.9 shifted", "go1.9beta1", 0x20, 0x10},
+		{"go1.9 shifted embedded", "go1.9", 0x21, 0x10},
+		{"go1.18 shifted", "go1.18.10", 0x21, 0x10},
+		{"go1.19 unshifted", "go1.19beta1", 0x10, 0x10},
+		{"go1.22 unshifted", "go1.22.0", 0x18, 0x18},
+		{"unknown version", "", 0x10, 0x10},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, structFieldOffset(test.offsetEmbed, test.goversion))
+		})
+	}
+}
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name      string
+		goversion string
+		data      []byte
+		expected  typeName
+		expectErr bool
+	}{
+		{"go1.8 name", "go1.8", []byte("\x01\x00\x04Name"), typeName{name: "Name"}, false},
+		{"go1.8 tag", "go1.8", []byte("\x03\x00\x01a\x00\x05json:"), typeName{name: "a", tag: "json:"}, false},
+		{"go1.8 embedded flag ignored", "go1.8", []byte("\x08\x00\x01a"), typeName{name: "a"}, false},
+		{"go1.17 name", "go1.17", []byte("\x00\x04name"), typeName{name: "name"}, false},
+		{"go1.17 tag", "go1.17", []byte("\x02\x01a\x05json:"), typeName{name: "a", tag: "json:"}, false},
+		{"go1.19 embedded", "go1.19", []byte("\x08\x01T"), typeName{name: "T", embedded: true}, false},
+		{"go1.8 truncated length", "go1.8", []byte("\x00\x00"), typeName{}, true},
+		{"go1.17 name out of bounds", "go1.17", []byte("\x00\x10name"), typeName{}, true},
+		{"go1.17 tag out of bounds", "go1.17", []byte("\x02\x01a\x10"), typeName{}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			fi := &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64, goversion: &GoVersion{Name: test.goversion}}
+			p := newTypeParser(test.data, 0, fi)
+			n, err := p.parseName(p, 0)
+			if test.expectErr {
+				r.Error(err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(test.expected, n)
+		})
+	}
+}
+
+func TestLegacyTypeNames(t *testing.T) {
+	for _, ver := range []string{"go1.5", "go1.6"} {
+		t.Run(ver, func(t *testing.T) {
+			r := require.New(t)
+			fi := &FileInfo{ByteOrder: binary.LittleEndian, WordSize: intSize64, goversion: ResolveGoVersion(ver)}
+			const base = 0x1000
+			data := make([]byte, 0x400)
+			put := func(off int, v uint64) { binary.LittleEndian.PutUint64(data[off:], v) }
+			strOff := 0x300
+			// putStr writes a string header at off pointing to the string.
+			putStr := func(off int, s string) {
+				copy(data[strOff:], s)
+				put(off, uint64(base+strOff))
+				put(off+8, uint64(len(s)))
+				strOff += len(s)
+			}
+			end := int(typeOffset(fi, _typeFieldEnd))
+			str := int(typeOffset(fi, _typeFieldStr))
+			kind := int(typeOffset(fi, _typeFieldKind))
+
+			// The struct type at offset 0 and the int type at offset 0x100.
+			data[kind] = uint8(reflect.Struct)
+			put(str, base+0x200)
+			putStr(0x200, "main.myStruct")
+			put(str+8, base+0x210)
+			put(0x210, base+0x240)
+			putStr(0x240, "myStruct")
+			put(0x218, base+0x250)
+			putStr(0x250, "main")
+			put(end, base+0x180)
+			put(end+8, 2)
+			put(end+16, 2)
+
+			data[0x100+kind] = uint8(reflect.Int)
+			put(0x100+str, base+0x260)
+			putStr(0x260, "int")
+
+			// The fields: a named field with a tag and an embedded field.
+			put(0x180, base+0x270)
+			putStr(0x270, "name")
+			put(0x180+16, base+0x100)
+			put(0x180+24, base+0x280)
+			putStr(0x280, `json:"name"`)
+			put(0x1a8+16, base+0x100)
+			put(0x1a8+32, 8)
+
+			typ := typeParse(make(map[uint64]*GoType), fi, 0, data, base)
+			r.NotNil(typ)
+			r.Equal(reflect.Struct, typ.Kind)
+			r.Equal("main.myStruct", typ.Name)
+			r.Equal("main", typ.PackagePath)
+			r.Len(typ.Fields, 2)
+			r.Equal("name", typ.Fields[0].FieldName)
+			r.Equal(`json:"name"`, typ.Fields[0].FieldTag)
+			r.Equal(reflect.Int, typ.Fields[0].Kind)
+			r.False(typ.Fields[0].FieldAnon)
+			r.Equal("", typ.Fields[1].FieldName)
+			r.True(typ.Fields[1].FieldAnon)
+			r.Equal(uint64(8), typ.Fields[1].FieldOffset)
+		})
+	}
+}
+
+func TestParseTypeMaxDepth(t *testing.T) {
+	const (
+		typesOff = 0x10
+		ptrSize  = 56
+		chainLen = 5
+	)
+	// The name "T" is followed by a chain of pointer types. The last pointer
+	// points to itself.
+	le := binary.LittleEndian
+	data := make([]byte, typesOff)
+	copy(data, "\x00\x01T")
+	for i := 0; i < chainLen; i++ {
+		buf := &bytes.Buffer{}
+		require.NoError(t, binary.Write(buf, le, rtypeGo64{Kind: uint8(reflect.Ptr)}))
+		elem := typesOff + ptrSize*min(i+1, chainLen-1)
+		data = le.AppendUint64(append(data, buf.Bytes()...), uint64(elem))
+	}
+
+	tests := []struct {
+		name      string
+		maxDepth  int
+		truncated int
+	}{
+		{"default", 0, -1},
+		{"limited", 3, 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			fi := &FileInfo{ByteOrder: le, WordSize: intSize64, goversion: &GoVersion{Name: "go1.22"}, maxTypeDepth: test.maxDepth}
+			p := newTypeParser(data, 0, fi)
+			typ, err := p.parseType(typesOff)
+			r.NoError(err)
+
+			for i := 0; i < chainLen; i++ {
+				r.Equal(reflect.Ptr, typ.Kind)
+				r.Equal("T", typ.Name)
+				r.Equal(i == test.truncated, typ.Truncated, "type %d", i)
+				if typ.Truncated {
+					r.Nil(typ.Element)
+					return
+				}
+				typ = typ.Element
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package gore
 
-import "io"
+import (
+	"io"
+	"math"
+	"sync"
+)
 
 func tryClose(r io.ReaderAt) error {
 	if c, ok := r.(io.Closer); ok {
@@ -8,3 +12,41 @@ func tryClose(r io.ReaderAt) error {
 	}
 	return nil
 }
+
+// closeGuard ensures that a resource is only closed once.
+type closeGuard struct {
+	mu     sync.Mutex
+	closed bool
+}
+
+// close calls fn the first time it's called. Subsequent calls return nil
+// without calling fn.
+func (g *closeGuard) close(fn func() error) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return nil
+	}
+	g.closed = true
+	return fn()
+}
+
+// entropy returns the Shannon entropy of the data in bits per byte.
+func entropy(data []byte) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	var counts [256]int
+	for _, b := range data {
+		counts[b]++
+	}
+	var e float64
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / float64(len(data))
+		e -= p * math.Log2(p)
+	}
+	return e
+}
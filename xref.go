@@ -0,0 +1,247 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"golang.org/x/arch/x86/x86asm"
+)
+
+// xrefMaxStringLen is the longest string literal resolved for an XRef.
+const xrefMaxStringLen = 4096
+
+// XRefKind is the kind of target referenced by an instruction.
+type XRefKind uint8
+
+const (
+	// XRefUnknown is used for references that could not be resolved, and for
+	// references made by instructions that are not resolved.
+	XRefUnknown XRefKind = iota
+	// XRefFunction is used for references to a function.
+	XRefFunction
+	// XRefType is used for references to a type.
+	XRefType
+	// XRefString is used for references to a string literal.
+	XRefString
+	// XRefData is used for references to other data.
+	XRefData
+)
+
+// XRef is a reference from an instruction to an address.
+type XRef struct {
+	// From is the address of the instruction.
+	From uint64
+	// To is the referenced address.
+	To uint64
+	// Kind is the kind of the referenced target.
+	Kind XRefKind
+	// Function is the referenced function if the kind is XRefFunction.
+	Function *Function
+	// Type is the referenced type if the kind is XRefType.
+	Type *GoType
+	// String is the referenced string if the kind is XRefString.
+	String string
+}
+
+// XRefs returns the references to addresses made by the instructions of the
+// function, in the order of the instructions. The targets of "call", "lea" and
+// "mov" instructions are resolved to a function, a type, a string literal or
+// other data. A string literal is detected by its address being loaded by a
+// "lea" followed by a "mov" of its length. References made by other
+// instructions have the kind XRefUnknown. Branches within the function are not
+// included. Bytes that can't be decoded as an instruction, for example an
+// encoding not supported by the disassembler, are skipped one at a time so
+// the rest of the function is still resolved. Only x86 binaries are supported,
+// for other architectures ErrUnsupportedArch is returned.
+func (f *GoFile) XRefs(fn *Function) ([]XRef, error) {
+	if f.FileInfo.Arch != Arch386 && f.FileInfo.Arch != ArchAMD64 {
+		return nil, ErrUnsupportedArch
+	}
+	buf, err := f.Bytes(fn.Offset, fn.End-fn.Offset)
+	if err != nil {
+		return nil, err
+	}
+	fns, err := f.Functions()
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode all the instructions first since a string literal is resolved
+	// from two instructions.
+	var insts []x86asm.Inst
+	mode := f.FileInfo.WordSize * 8
+	for s := 0; s < len(buf); {
+		inst, err := x86asm.Decode(buf[s:], mode)
+		if err != nil {
+			// Skip the byte. The empty instruction keeps the addresses of
+			// the following instructions correct and has no references.
+			inst = x86asm.Inst{Len: 1}
+		}
+		insts = append(insts, inst)
+		s += inst.Len
+	}
+
+	r := &xrefResolver{f: f, fns: fns}
+	var refs []XRef
+	pc := fn.Offset
+	for i, inst := range insts {
+		from := pc
+		pc += uint64(inst.Len)
+
+		to, ok := x86RefTarget(inst, pc)
+		if !ok || !r.isAddress(to) {
+			continue
+		}
+		if inst.Op != x86asm.CALL && fn.Offset <= to && to < fn.End {
+			// A branch or a load within the function.
+			continue
+		}
+
+		ref := XRef{From: from, To: to}
+		switch inst.Op {
+		case x86asm.CALL:
+			ref.Function = r.function(to)
+		case x86asm.LEA, x86asm.MOV:
+			if ref.Function = r.function(to); ref.Function != nil {
+				break
+			}
+			if ref.Type = r.goType(to); ref.Type != nil {
+				break
+			}
+			if inst.Op == x86asm.LEA && i+1 < len(insts) {
+				ref.String, ok = r.stringLiteral(to, insts[i+1])
+				if ok {
+					ref.Kind = XRefString
+					break
+				}
+			}
+			ref.Kind = XRefData
+		}
+		if ref.Function != nil {
+			ref.Kind = XRefFunction
+		} else if ref.Type != nil {
+			ref.Kind = XRefType
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// x86RefTarget returns the address referenced by the instruction, either as
+// a relative branch target, an instruction pointer relative memory operand or
+// an absolute memory operand. The pc is the address after the instruction.
+func x86RefTarget(inst x86asm.Inst, pc uint64) (uint64, bool) {
+	for _, arg := range inst.Args {
+		switch a := arg.(type) {
+		case x86asm.Rel:
+			return uint64(int64(pc) + int64(a)), true
+		case x86asm.Mem:
+			if a.Base == x86asm.RIP || a.Base == x86asm.EIP {
+				return uint64(int64(pc) + a.Disp), true
+			}
+			// Absolute addressing used by 32-bit code. Segment based addressing is
+			// used for thread local storage.
+			if a.Base == 0 && a.Index == 0 && a.Segment == 0 && a.Disp > 0 {
+				return uint64(a.Disp), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// xrefResolver resolves the targets of the references.
+type xrefResolver struct {
+	f *GoFile
+	// fns are the functions sorted by their offset.
+	fns []*Function
+	// types is the parser used for the types data. It's created the first time
+	// a type is resolved.
+	types     *typeParser
+	typesInit bool
+}
+
+// isAddress returns true if the address is located in a section of the file.
+func (r *xrefResolver) isAddress(addr uint64) bool {
+	_, _, err := r.f.fh.getSectionDataFromAddress(addr)
+	return err == nil
+}
+
+// function returns the function that holds the address or nil.
+func (r *xrefResolver) function(addr uint64) *Function {
+	i := sort.Search(len(r.fns), func(i int) bool {
+		return r.fns[i].End > addr
+	})
+	if i == len(r.fns) || r.fns[i].Offset > addr {
+		return nil
+	}
+	return r.fns[i]
+}
+
+// goType returns the type located at the address or nil. Types are only
+// resolved for binaries compiled with Go 1.7 or later.
+func (r *xrefResolver) goType(addr uint64) *GoType {
+	if !r.typesInit {
+		r.typesInit = true
+		r.types = r.newTypeParser()
+	}
+	if r.types == nil || addr < r.types.base || addr >= r.types.base+uint64(len(r.types.typesData)) {
+		return nil
+	}
+	typ, err := r.types.parseType(addr)
+	if err != nil {
+		return nil
+	}
+	return typ
+}
+
+func (r *xrefResolver) newTypeParser() *typeParser {
+	if r.f.FileInfo.goversion == nil || GoVersionCompare(r.f.FileInfo.goversion.Name, "go1.7beta1") < 0 {
+		return nil
+	}
+	md, err := r.f.Moduledata()
+	if err != nil {
+		return nil
+	}
+	data, err := md.Types().Data()
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	return newTypeParser(data, md.Types().Address, r.f.FileInfo)
+}
+
+// stringLiteral returns the string at the address if the next instruction
+// moves its length into a register.
+func (r *xrefResolver) stringLiteral(addr uint64, next x86asm.Inst) (string, bool) {
+	if next.Op != x86asm.MOV {
+		return "", false
+	}
+	if _, ok := next.Args[0].(x86asm.Reg); !ok {
+		return "", false
+	}
+	l, ok := next.Args[1].(x86asm.Imm)
+	if !ok || l <= 0 || l > xrefMaxStringLen {
+		return "", false
+	}
+	data, err := r.f.Bytes(addr, uint64(l))
+	if err != nil || !utf8.Valid(data) {
+		return "", false
+	}
+	return string(data), true
+}
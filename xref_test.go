@@ -0,0 +1,112 @@
+// This file is part of GoRE.
+//
+// Copyright (C) 2019-2024 GoRE Authors
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package gore
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXRefs(t *testing.T) {
+	const (
+		codeAddr = uint64(0x10000)
+		dataAddr = uint64(0x11000)
+	)
+	le := binary.LittleEndian
+
+	var code []byte
+	// call 0x10100
+	code = le.AppendUint32(append(code, 0xe8), 0xfb)
+	// lea rax, [rip+0xff4]; mov ebx, 5
+	code = le.AppendUint32(append(code, 0x48, 0x8d, 0x05), 0xff4)
+	code = le.AppendUint32(append(code, 0xbb), 5)
+	// mov rcx, [rip+0x10e8]
+	code = le.AppendUint32(append(code, 0x48, 0x8b, 0x0d), 0x10e8)
+	// jmp 0x1001a
+	code = append(code, 0xeb, 0x00)
+	// jmp 0x10100
+	code = le.AppendUint32(append(code, 0xe9), 0xe1)
+	// ret
+	code = append(code, 0xc3)
+
+	codeSect := make([]byte, 0x200)
+	copy(codeSect, code)
+	codeSect[0x100] = 0xc3
+
+	// An invalid instruction in 64-bit mode followed by "call 0x10100".
+	var undecodable []byte
+	undecodable = le.AppendUint32(append(undecodable, 0x06, 0xe8), 0x7a)
+	copy(codeSect[0x80:], undecodable)
+	dataSect := make([]byte, 0x200)
+	copy(dataSect, "hello")
+
+	caller := &Function{Name: "caller", Offset: codeAddr, End: codeAddr + uint64(len(code))}
+	skipping := &Function{Name: "skipping", Offset: codeAddr + 0x80, End: codeAddr + 0x80 + uint64(len(undecodable))}
+	callee := &Function{Name: "callee", Offset: codeAddr + 0x100, End: codeAddr + 0x101}
+	fh := &mockFileHandler{
+		mGetSectionDataFromAddress: func(a uint64) (uint64, []byte, error) {
+			switch {
+			case a >= codeAddr && a < codeAddr+uint64(len(codeSect)):
+				return codeAddr, codeSect, nil
+			case a >= dataAddr && a < dataAddr+uint64(len(dataSect)):
+				return dataAddr, dataSect, nil
+			}
+			return 0, nil, ErrSectionDoesNotExist
+		},
+	}
+
+	t.Run("amd64", func(t *testing.T) {
+		r := require.New(t)
+		f := newTestGoFile(fh, &FileInfo{Arch: ArchAMD64, WordSize: intSize64, ByteOrder: le}, &Package{
+			Name:      "main",
+			Functions: []*Function{caller, callee},
+		})
+
+		refs, err := f.XRefs(caller)
+		r.NoError(err)
+		r.Equal([]XRef{
+			{From: codeAddr, To: callee.Offset, Kind: XRefFunction, Function: callee},
+			{From: codeAddr + 5, To: dataAddr, Kind: XRefString, String: "hello"},
+			{From: codeAddr + 17, To: dataAddr + 0x100, Kind: XRefData},
+			{From: codeAddr + 26, To: callee.Offset, Kind: XRefUnknown},
+		}, refs)
+	})
+
+	t.Run("undecodable instruction", func(t *testing.T) {
+		r := require.New(t)
+		f := newTestGoFile(fh, &FileInfo{Arch: ArchAMD64, WordSize: intSize64, ByteOrder: le}, &Package{
+			Name:      "main",
+			Functions: []*Function{caller, skipping, callee},
+		})
+
+		refs, err := f.XRefs(skipping)
+		r.NoError(err)
+		r.Equal([]XRef{
+			{From: skipping.Offset + 1, To: callee.Offset, Kind: XRefFunction, Function: callee},
+		}, refs)
+	})
+
+	t.Run("unsupported arch", func(t *testing.T) {
+		f := newTestGoFile(fh, &FileInfo{Arch: ArchMIPS, WordSize: intSize32, ByteOrder: le})
+		_, err := f.XRefs(caller)
+		assert.ErrorIs(t, err, ErrUnsupportedArch)
+	})
+}